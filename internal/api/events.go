@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"sentire/internal/client"
 	"sentire/pkg/models"
@@ -28,7 +30,7 @@ type ListProjectEventsOptions struct {
 }
 
 // ListProjectEvents retrieves events for a specific project
-func (e *EventsAPI) ListProjectEvents(orgSlug, projectSlug string, opts *ListProjectEventsOptions) ([]models.Event, *client.PaginationInfo, error) {
+func (e *EventsAPI) ListProjectEvents(ctx context.Context, orgSlug, projectSlug string, opts *ListProjectEventsOptions) ([]models.Event, *client.PaginationInfo, error) {
 	endpoint := fmt.Sprintf("/projects/%s/%s/events/", orgSlug, projectSlug)
 
 	params := url.Values{}
@@ -53,7 +55,7 @@ func (e *EventsAPI) ListProjectEvents(orgSlug, projectSlug string, opts *ListPro
 		}
 	}
 
-	resp, err := e.client.Get(endpoint, params)
+	resp, err := e.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -66,6 +68,18 @@ func (e *EventsAPI) ListProjectEvents(orgSlug, projectSlug string, opts *ListPro
 	return events, resp.Pagination, nil
 }
 
+// IterProjectEvents iterates over the events of a project across pages, as controlled by pager
+func (e *EventsAPI) IterProjectEvents(ctx context.Context, orgSlug, projectSlug string, opts *ListProjectEventsOptions, pager *client.Paginator) iter.Seq2[models.Event, error] {
+	pageOpts := ListProjectEventsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Event, *client.PaginationInfo, error) {
+		pageOpts.Cursor = cursor
+		return e.ListProjectEvents(ctx, orgSlug, projectSlug, &pageOpts)
+	})
+}
+
 // ListIssueEventsOptions contains options for listing issue events
 type ListIssueEventsOptions struct {
 	Start       string
@@ -79,7 +93,7 @@ type ListIssueEventsOptions struct {
 }
 
 // ListIssueEvents retrieves events for a specific issue
-func (e *EventsAPI) ListIssueEvents(orgSlug string, issueID string, opts *ListIssueEventsOptions) ([]models.Event, *client.PaginationInfo, error) {
+func (e *EventsAPI) ListIssueEvents(ctx context.Context, orgSlug string, issueID string, opts *ListIssueEventsOptions) ([]models.Event, *client.PaginationInfo, error) {
 	endpoint := fmt.Sprintf("/organizations/%s/issues/%s/events/", orgSlug, issueID)
 
 	params := url.Values{}
@@ -110,7 +124,7 @@ func (e *EventsAPI) ListIssueEvents(orgSlug string, issueID string, opts *ListIs
 		}
 	}
 
-	resp, err := e.client.Get(endpoint, params)
+	resp, err := e.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -123,6 +137,18 @@ func (e *EventsAPI) ListIssueEvents(orgSlug string, issueID string, opts *ListIs
 	return events, resp.Pagination, nil
 }
 
+// IterIssueEvents iterates over the events of an issue across pages, as controlled by pager
+func (e *EventsAPI) IterIssueEvents(ctx context.Context, orgSlug, issueID string, opts *ListIssueEventsOptions, pager *client.Paginator) iter.Seq2[models.Event, error] {
+	pageOpts := ListIssueEventsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Event, *client.PaginationInfo, error) {
+		pageOpts.Cursor = cursor
+		return e.ListIssueEvents(ctx, orgSlug, issueID, &pageOpts)
+	})
+}
+
 // ListIssuesOptions contains options for listing organization issues
 type ListIssuesOptions struct {
 	Environment []string
@@ -137,7 +163,7 @@ type ListIssuesOptions struct {
 }
 
 // ListIssues retrieves issues for an organization
-func (e *EventsAPI) ListIssues(orgSlug string, opts *ListIssuesOptions) ([]models.Issue, *client.PaginationInfo, error) {
+func (e *EventsAPI) ListIssues(ctx context.Context, orgSlug string, opts *ListIssuesOptions) ([]models.Issue, *client.PaginationInfo, error) {
 	endpoint := fmt.Sprintf("/organizations/%s/issues/", orgSlug)
 
 	params := url.Values{}
@@ -171,7 +197,7 @@ func (e *EventsAPI) ListIssues(orgSlug string, opts *ListIssuesOptions) ([]model
 		}
 	}
 
-	resp, err := e.client.Get(endpoint, params)
+	resp, err := e.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -184,11 +210,23 @@ func (e *EventsAPI) ListIssues(orgSlug string, opts *ListIssuesOptions) ([]model
 	return issues, resp.Pagination, nil
 }
 
+// IterIssues iterates over the issues of an organization across pages, as controlled by pager
+func (e *EventsAPI) IterIssues(ctx context.Context, orgSlug string, opts *ListIssuesOptions, pager *client.Paginator) iter.Seq2[models.Issue, error] {
+	pageOpts := ListIssuesOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Issue, *client.PaginationInfo, error) {
+		pageOpts.Cursor = cursor
+		return e.ListIssues(ctx, orgSlug, &pageOpts)
+	})
+}
+
 // GetProjectEvent retrieves a specific event for a project
-func (e *EventsAPI) GetProjectEvent(orgSlug, projectSlug, eventID string) (*models.Event, error) {
+func (e *EventsAPI) GetProjectEvent(ctx context.Context, orgSlug, projectSlug, eventID string) (*models.Event, error) {
 	endpoint := fmt.Sprintf("/projects/%s/%s/events/%s/", orgSlug, projectSlug, eventID)
 
-	resp, err := e.client.Get(endpoint, nil)
+	resp, err := e.client.Get(ctx, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -202,10 +240,10 @@ func (e *EventsAPI) GetProjectEvent(orgSlug, projectSlug, eventID string) (*mode
 }
 
 // GetIssue retrieves a specific issue
-func (e *EventsAPI) GetIssue(orgSlug, issueID string) (*models.Issue, error) {
+func (e *EventsAPI) GetIssue(ctx context.Context, orgSlug, issueID string) (*models.Issue, error) {
 	endpoint := fmt.Sprintf("/organizations/%s/issues/%s/", orgSlug, issueID)
 
-	resp, err := e.client.Get(endpoint, nil)
+	resp, err := e.client.Get(ctx, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +262,7 @@ type GetIssueEventOptions struct {
 }
 
 // GetIssueEvent retrieves a specific event for an issue
-func (e *EventsAPI) GetIssueEvent(orgSlug, issueID, eventID string, opts *GetIssueEventOptions) (*models.Event, error) {
+func (e *EventsAPI) GetIssueEvent(ctx context.Context, orgSlug, issueID, eventID string, opts *GetIssueEventOptions) (*models.Event, error) {
 	endpoint := fmt.Sprintf("/organizations/%s/issues/%s/events/%s/", orgSlug, issueID, eventID)
 
 	params := url.Values{}
@@ -234,7 +272,7 @@ func (e *EventsAPI) GetIssueEvent(orgSlug, issueID, eventID string, opts *GetIss
 		}
 	}
 
-	resp, err := e.client.Get(endpoint, params)
+	resp, err := e.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"iter"
 	"net/url"
 	"sentire/internal/client"
 	"sentire/pkg/models"
@@ -17,13 +20,40 @@ func NewOrganizationsAPI(client *client.Client) *OrganizationsAPI {
 	return &OrganizationsAPI{client: client}
 }
 
+// ListOrganizationsOptions contains options for listing organizations
+type ListOrganizationsOptions struct {
+	Cursor string
+}
+
+// ListOrganizations retrieves the organizations the token has access to
+func (o *OrganizationsAPI) ListOrganizations(ctx context.Context, opts *ListOrganizationsOptions) ([]models.Organization, *client.PaginationInfo, error) {
+	endpoint := "/organizations/"
+
+	params := url.Values{}
+	if opts != nil && opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	}
+
+	resp, err := o.client.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var organizations []models.Organization
+	if err := o.client.DecodeJSON(resp, &organizations); err != nil {
+		return nil, nil, err
+	}
+
+	return organizations, resp.Pagination, nil
+}
+
 // ListProjectsOptions contains options for listing organization projects
 type ListProjectsOptions struct {
 	Cursor string
 }
 
 // ListProjects retrieves projects for an organization
-func (o *OrganizationsAPI) ListProjects(orgSlug string, opts *ListProjectsOptions) ([]models.Project, *client.PaginationInfo, error) {
+func (o *OrganizationsAPI) ListProjects(ctx context.Context, orgSlug string, opts *ListProjectsOptions) ([]models.Project, *client.PaginationInfo, error) {
 	endpoint := fmt.Sprintf("/organizations/%s/projects/", orgSlug)
 
 	params := url.Values{}
@@ -31,7 +61,7 @@ func (o *OrganizationsAPI) ListProjects(orgSlug string, opts *ListProjectsOption
 		params.Set("cursor", opts.Cursor)
 	}
 
-	resp, err := o.client.Get(endpoint, params)
+	resp, err := o.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -44,6 +74,18 @@ func (o *OrganizationsAPI) ListProjects(orgSlug string, opts *ListProjectsOption
 	return projects, resp.Pagination, nil
 }
 
+// IterProjects iterates over the projects of an organization across pages, as controlled by pager
+func (o *OrganizationsAPI) IterProjects(ctx context.Context, orgSlug string, opts *ListProjectsOptions, pager *client.Paginator) iter.Seq2[models.Project, error] {
+	pageOpts := ListProjectsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Project, *client.PaginationInfo, error) {
+		pageOpts.Cursor = cursor
+		return o.ListProjects(ctx, orgSlug, &pageOpts)
+	})
+}
+
 // GetStatsOptions contains options for retrieving organization statistics
 type GetStatsOptions struct {
 	Field       string   // Required: "sum(quantity)" or "sum(times_seen)"
@@ -55,17 +97,60 @@ type GetStatsOptions struct {
 	Category    []string // Event category filters
 	Outcome     []string // Event outcome filters
 	Reason      []string // Event reason filters
-	Download    bool     // Download as CSV
 }
 
 // GetStats retrieves event statistics for an organization
-func (o *OrganizationsAPI) GetStats(orgSlug string, opts *GetStatsOptions) (*models.OrganizationStats, error) {
+func (o *OrganizationsAPI) GetStats(ctx context.Context, orgSlug string, opts *GetStatsOptions) (*models.OrganizationStats, error) {
+	params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Get(ctx, statsEndpoint(orgSlug), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats models.OrganizationStats
+	if err := o.client.DecodeJSON(resp, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// DownloadStats retrieves event statistics for an organization as the CSV
+// file Sentry generates for download
+func (o *OrganizationsAPI) DownloadStats(ctx context.Context, orgSlug string, opts *GetStatsOptions) ([]byte, error) {
+	params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("download", "true")
+
+	resp, err := o.client.Get(ctx, statsEndpoint(orgSlug), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats download: %w", err)
+	}
+	return data, nil
+}
+
+func statsEndpoint(orgSlug string) string {
+	return fmt.Sprintf("/organizations/%s/stats-summary/", orgSlug)
+}
+
+// params returns the query parameters of a stats request
+func (opts *GetStatsOptions) params() (url.Values, error) {
 	if opts == nil || opts.Field == "" {
 		return nil, fmt.Errorf("field parameter is required")
 	}
 
-	endpoint := fmt.Sprintf("/organizations/%s/stats-summary/", orgSlug)
-
 	params := url.Values{}
 	params.Set("field", opts.Field)
 
@@ -93,19 +178,5 @@ func (o *OrganizationsAPI) GetStats(orgSlug string, opts *GetStatsOptions) (*mod
 	for _, reason := range opts.Reason {
 		params.Add("reason", reason)
 	}
-	if opts.Download {
-		params.Set("download", "true")
-	}
-
-	resp, err := o.client.Get(endpoint, params)
-	if err != nil {
-		return nil, err
-	}
-
-	var stats models.OrganizationStats
-	if err := o.client.DecodeJSON(resp, &stats); err != nil {
-		return nil, err
-	}
-
-	return &stats, nil
+	return params, nil
 }
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"sentire/internal/client"
 	"sentire/pkg/models"
@@ -23,7 +25,7 @@ type ListAllProjectsOptions struct {
 }
 
 // ListProjects retrieves all projects the user has access to
-func (p *ProjectsAPI) ListProjects(opts *ListAllProjectsOptions) ([]models.Project, *client.PaginationInfo, error) {
+func (p *ProjectsAPI) ListProjects(ctx context.Context, opts *ListAllProjectsOptions) ([]models.Project, *client.PaginationInfo, error) {
 	endpoint := "/projects/"
 
 	params := url.Values{}
@@ -31,7 +33,7 @@ func (p *ProjectsAPI) ListProjects(opts *ListAllProjectsOptions) ([]models.Proje
 		params.Set("cursor", opts.Cursor)
 	}
 
-	resp, err := p.client.Get(endpoint, params)
+	resp, err := p.client.Get(ctx, endpoint, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -44,11 +46,23 @@ func (p *ProjectsAPI) ListProjects(opts *ListAllProjectsOptions) ([]models.Proje
 	return projects, resp.Pagination, nil
 }
 
+// IterProjects iterates over all projects the user has access to across pages, as controlled by pager
+func (p *ProjectsAPI) IterProjects(ctx context.Context, opts *ListAllProjectsOptions, pager *client.Paginator) iter.Seq2[models.Project, error] {
+	pageOpts := ListAllProjectsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Project, *client.PaginationInfo, error) {
+		pageOpts.Cursor = cursor
+		return p.ListProjects(ctx, &pageOpts)
+	})
+}
+
 // GetProject retrieves a specific project
-func (p *ProjectsAPI) GetProject(orgSlug, projectSlug string) (*models.Project, error) {
+func (p *ProjectsAPI) GetProject(ctx context.Context, orgSlug, projectSlug string) (*models.Project, error) {
 	endpoint := fmt.Sprintf("/projects/%s/%s/", orgSlug, projectSlug)
 
-	resp, err := p.client.Get(endpoint, nil)
+	resp, err := p.client.Get(ctx, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,219 @@
+// Package archive stores issues and events on disk so they can be read
+// without access to the Sentry API.
+//
+// An archive is a directory of JSON files, one directory per organization:
+//
+//	<dir>/<org>/issues/<issue-id>.json
+//	<dir>/<org>/events/<issue-id>/<event-id>.json
+//
+// Events are stored under their event ID and under the aliases they were
+// fetched by (recommended, latest), so lookups work with either.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"sentire/internal/api"
+	"sentire/internal/client"
+	"sentire/pkg/models"
+	"sort"
+	"strings"
+)
+
+// NotFoundError reports an issue or event that is not in the archive
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// Archive is a local store of issues and events
+type Archive struct {
+	Dir string
+}
+
+// DefaultDir returns the archive directory under the user data directory,
+// $XDG_DATA_HOME/sentire/archive or ~/.local/share/sentire/archive
+func DefaultDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "sentire", "archive"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine archive directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "sentire", "archive"), nil
+}
+
+// Open returns the archive stored in dir
+func Open(dir string) *Archive {
+	return &Archive{Dir: dir}
+}
+
+// SaveIssue stores an issue of org, replacing any previous copy
+func (a *Archive) SaveIssue(org string, issue *models.Issue) error {
+	path, err := a.path(org, "issues", issue.ID+".json")
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, issue)
+}
+
+// SaveEvent stores an event of an issue under eventID, which may be an
+// alias such as "recommended", as well as under the event's own ID
+func (a *Archive) SaveEvent(org, issueID, eventID string, event *models.Event) error {
+	names := []string{eventID}
+	if event.EventID != "" && event.EventID != eventID {
+		names = append(names, event.EventID)
+	}
+
+	for _, name := range names {
+		path, err := a.path(org, "events", issueID, name+".json")
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(path, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIssue returns an archived issue, looked up by ID or short ID
+func (a *Archive) GetIssue(ctx context.Context, org, issueID string) (*models.Issue, error) {
+	path, err := a.path(org, "issues", issueID+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var issue models.Issue
+	err = readJSON(path, &issue)
+	if err == nil {
+		return &issue, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	// Fall back to matching short IDs such as PROJECT-1A
+	issues, err := a.issues(org)
+	if err != nil {
+		return nil, err
+	}
+	for i := range issues {
+		if strings.EqualFold(issues[i].ShortID, issueID) {
+			return &issues[i], nil
+		}
+	}
+	return nil, &NotFoundError{Message: fmt.Sprintf("issue %s of organization %s is not in the archive (run 'sentire archive pull %s')", issueID, org, org)}
+}
+
+// GetIssueEvent returns an archived event of an issue. eventID is an event
+// ID or one of the aliases the event was archived under. opts is accepted
+// for compatibility with api.EventsAPI and ignored.
+func (a *Archive) GetIssueEvent(ctx context.Context, org, issueID, eventID string, opts *api.GetIssueEventOptions) (*models.Event, error) {
+	issue, err := a.GetIssue(ctx, org, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := a.path(org, "events", issue.ID, eventID+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	if err := readJSON(path, &event); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, &NotFoundError{Message: fmt.Sprintf("event %s of issue %s is not in the archive", eventID, issueID)}
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// IterIssues iterates over the archived issues of org, most recently seen
+// first. Search options cannot be evaluated offline and are ignored, so
+// callers should reject them; pager limits are applied.
+func (a *Archive) IterIssues(ctx context.Context, org string, opts *api.ListIssuesOptions, pager *client.Paginator) iter.Seq2[models.Issue, error] {
+	// The archive is read as a single page
+	return client.Paginate(ctx, pager, func(ctx context.Context, cursor string) ([]models.Issue, *client.PaginationInfo, error) {
+		issues, err := a.issues(org)
+		if err != nil {
+			return nil, nil, err
+		}
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].LastSeen.After(issues[j].LastSeen)
+		})
+		return issues, nil, nil
+	})
+}
+
+// issues reads all archived issues of org
+func (a *Archive) issues(org string) ([]models.Issue, error) {
+	dir, err := a.path(org, "issues")
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &NotFoundError{Message: fmt.Sprintf("organization %s is not in the archive (run 'sentire archive pull %s')", org, org)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	var issues []models.Issue
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var issue models.Issue
+		if err := readJSON(filepath.Join(dir, entry.Name()), &issue); err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// path joins elements below the directory of org, rejecting elements that
+// would escape it
+func (a *Archive) path(org string, elem ...string) (string, error) {
+	for _, e := range append([]string{org}, elem...) {
+		if e == "" || e == "." || e == ".." || strings.ContainsAny(e, `/\`) {
+			return "", fmt.Errorf("invalid archive path element %q", e)
+		}
+	}
+	return filepath.Join(append([]string{a.Dir, org}, elem...)...), nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
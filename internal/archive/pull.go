@@ -0,0 +1,87 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sentire/internal/api"
+	"sentire/internal/client"
+	"sentire/pkg/models"
+)
+
+// PullEvents are the events archived for every issue
+var PullEvents = []string{"recommended", "latest"}
+
+// PullStats counts what a pull archived
+type PullStats struct {
+	Issues int
+	Events int
+	// Failures lists the issues archived without some of their events
+	Failures []PullFailure
+}
+
+// PullFailure is an issue some of whose events could not be fetched
+type PullFailure struct {
+	IssueID string
+	Err     error
+}
+
+// Pull downloads the issues of org matching opts, across all pages, along
+// with the PullEvents of each, and stores them in the archive. Events are
+// fetched in parallel as bounded by c.Concurrency. An event that cannot be
+// fetched, such as one that was deleted, is recorded in the stats' Failures
+// and the pull goes on; the issue is archived with its other events.
+// progress, if not nil, is called after each archived issue.
+func (a *Archive) Pull(ctx context.Context, c *client.Client, org string, opts *api.ListIssuesOptions, progress func(PullStats)) (*PullStats, error) {
+	eventsAPI := api.NewEventsAPI(c)
+	issues := eventsAPI.IterIssues(ctx, org, opts, &client.Paginator{})
+
+	type archived struct {
+		issue  models.Issue
+		events map[string]*models.Event
+		errs   []error
+	}
+	fetched := client.FanOut(ctx, c, issues, func(ctx context.Context, issue models.Issue) (archived, error) {
+		result := archived{issue: issue, events: make(map[string]*models.Event, len(PullEvents))}
+		for _, eventID := range PullEvents {
+			event, err := eventsAPI.GetIssueEvent(ctx, org, issue.ID, eventID, nil)
+			if err != nil {
+				if ctx.Err() != nil {
+					return archived{}, err
+				}
+				result.errs = append(result.errs, fmt.Errorf("%s event: %w", eventID, err))
+				continue
+			}
+			result.events[eventID] = event
+		}
+		return result, nil
+	})
+
+	stats := &PullStats{}
+	for result, err := range fetched {
+		if err != nil {
+			return stats, err
+		}
+		if err := a.SaveIssue(org, &result.issue); err != nil {
+			return stats, err
+		}
+		for _, eventID := range PullEvents {
+			event, ok := result.events[eventID]
+			if !ok {
+				continue
+			}
+			if err := a.SaveEvent(org, result.issue.ID, eventID, event); err != nil {
+				return stats, err
+			}
+			stats.Events++
+		}
+		if len(result.errs) > 0 {
+			stats.Failures = append(stats.Failures, PullFailure{IssueID: result.issue.ID, Err: errors.Join(result.errs...)})
+		}
+		stats.Issues++
+		if progress != nil {
+			progress(*stats)
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"sentire/internal/api"
+	"sentire/internal/archive"
+	"sentire/internal/client"
+	"sentire/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Manage the local issue archive",
+	Long:  "Download issues and their events into a local archive (~/.local/share/sentire/archive) that the global --offline flag reads from",
+}
+
+var archivePullCmd = &cobra.Command{
+	Use:   "pull <organization>",
+	Short: "Download issues and events into the archive",
+	Long:  "Download the issues of an organization seen within --period, with the recommended and latest event of each, into the local archive. Issues already archived are updated.",
+	Args:  cobra.RangeArgs(0, 1),
+	RunE:  runArchivePull,
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	archiveCmd.AddCommand(archivePullCmd)
+
+	archivePullCmd.Flags().String("period", "14d", "Time period of issues to archive (e.g., '24h', '14d')")
+	archivePullCmd.Flags().String("query", "is:unresolved", "Search/filter query")
+	archivePullCmd.Flags().StringSlice("environment", nil, "Filter by environments")
+	archivePullCmd.Flags().StringSlice("project", nil, "Filter by project IDs")
+}
+
+// offlineAnnotation marks the commands that can answer from the archive
+const offlineAnnotation = "sentire/offline"
+
+// checkOffline rejects --offline for commands that need the API
+func checkOffline(cmd *cobra.Command) error {
+	if isOffline(cmd) && cmd.Annotations[offlineAnnotation] != "true" {
+		return NewInvalidInputError(fmt.Sprintf("%q does not support --offline", cmd.CommandPath()))
+	}
+	return nil
+}
+
+// issueSource looks up issues and their events, either through the API or,
+// with --offline, in the local archive
+type issueSource interface {
+	GetIssue(ctx context.Context, orgSlug, issueID string) (*models.Issue, error)
+	GetIssueEvent(ctx context.Context, orgSlug, issueID, eventID string, opts *api.GetIssueEventOptions) (*models.Event, error)
+	IterIssues(ctx context.Context, orgSlug string, opts *api.ListIssuesOptions, pager *client.Paginator) iter.Seq2[models.Issue, error]
+}
+
+// isOffline reports whether the --offline flag is set
+func isOffline(cmd *cobra.Command) bool {
+	offline, _ := cmd.Flags().GetBool("offline")
+	return offline
+}
+
+// newIssueSource returns the archive when --offline is set, or the events
+// API otherwise. The client is nil when offline.
+func newIssueSource(cmd *cobra.Command) (issueSource, *client.Client, error) {
+	if isOffline(cmd) {
+		dir, err := archive.DefaultDir()
+		if err != nil {
+			return nil, nil, err
+		}
+		return archive.Open(dir), nil, nil
+	}
+
+	c, err := newClient(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return api.NewEventsAPI(c), c, nil
+}
+
+func runArchivePull(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+	orgSlug := args[0]
+
+	if err := validateOrgSlug(orgSlug); err != nil {
+		return err
+	}
+
+	c, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	dir, err := archive.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	opts := &api.ListIssuesOptions{}
+	opts.StatsPeriod, _ = cmd.Flags().GetString("period")
+	opts.Query, _ = cmd.Flags().GetString("query")
+	opts.Environment, _ = cmd.Flags().GetStringSlice("environment")
+	opts.Project, _ = cmd.Flags().GetStringSlice("project")
+
+	progress := func(stats archive.PullStats) {
+		if stats.Issues%50 == 0 {
+			fmt.Fprintf(os.Stderr, "Archived %d issues...\n", stats.Issues)
+		}
+	}
+
+	stats, err := archive.Open(dir).Pull(cmd.Context(), c, orgSlug, opts, progress)
+	if err != nil {
+		return err
+	}
+
+	for _, failure := range stats.Failures {
+		fmt.Fprintf(os.Stderr, "Warning: issue %s was archived without some of its events: %v\n", failure.IssueID, failure.Err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Archived %d issues and %d events of %s in %s\n", stats.Issues, stats.Events, orgSlug, dir)
+	if n := len(stats.Failures); n > 0 {
+		noun := "issues"
+		if n == 1 {
+			noun = "issue"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%d %s archived without all of their events\n", n, noun)
+	}
+	return nil
+}
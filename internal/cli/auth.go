@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sentire/internal/api"
+	"sentire/internal/client"
+	"sentire/internal/config"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authentication",
+	Long:  "Commands for logging in to Sentry and managing API tokens",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with a Sentry API token",
+	Long:  "Prompt for a Sentry API token, validate it against the API and save it to the config file. Use the global --profile flag to save it to a named profile and --url for self-hosted instances.",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthLogin,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which credentials are in use",
+	Long:  "Show the API token, Sentry URL and defaults sentire would use, and where each one was found: the --profile flag, environment variables (SENTRY_API_TOKEN, SENTRY_AUTH_TOKEN, SENTRY_URL, SENTRY_ORG, SENTRY_PROJECT), the config file or a .sentryclirc file.",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	// Flags for login command
+	authLoginCmd.Flags().String("token", "", "API token to save instead of prompting for it")
+	authLoginCmd.Flags().Bool("skip-validation", false, "Save the token without checking it against the API")
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	profile, _ := cmd.Flags().GetString("profile")
+	sentryURL, _ := cmd.Flags().GetString("url")
+	if sentryURL != "" {
+		if err := validateSentryURL(sentryURL); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token, err = promptToken(cmd)
+		if err != nil {
+			return err
+		}
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return NewInvalidInputError("no API token provided")
+	}
+
+	if skip, _ := cmd.Flags().GetBool("skip-validation"); !skip {
+		if err := validateToken(cmd, cfg, token, sentryURL, profile); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Set(config.KeySentryAPIToken, token, profile); err != nil {
+		return err
+	}
+	if sentryURL != "" {
+		if err := cfg.Set(config.KeySentryURL, sentryURL, profile); err != nil {
+			return err
+		}
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		return err
+	}
+
+	path, _ := config.ConfigPath()
+	if profile != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Token saved to profile %q in %s\n", profile, path)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Token saved to %s\n", path)
+	}
+	return nil
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	// A missing token is reported rather than treated as an error
+	profile, _ := cmd.Flags().GetString("profile")
+	cfg, err := config.ResolveConfig(config.LoadOptions{Profile: profile})
+	if err != nil {
+		return err
+	}
+	if cfg.SentryAPIToken == "" && cfg.TokenSource != nil {
+		if cfg.SentryAPIToken, err = cfg.TokenSource.Token(); err != nil {
+			return err
+		}
+	}
+	if err := applyURLFlag(cmd, cfg); err != nil {
+		return err
+	}
+
+	sentryURL := cfg.SentryURL
+	if sentryURL == "" {
+		sentryURL = config.DefaultSentryURL
+	}
+
+	out := cmd.OutOrStdout()
+	if cfg.Profile != "" {
+		fmt.Fprintf(out, "Profile:      %s\n", cfg.Profile)
+	}
+	fmt.Fprintf(out, "Token:        %s\n", sourcedValue(cfg, config.KeySentryAPIToken, maskToken(cfg.SentryAPIToken)))
+	fmt.Fprintf(out, "Sentry URL:   %s (%s)\n", sentryURL, valueSource(cfg, config.KeySentryURL))
+	fmt.Fprintf(out, "Organization: %s\n", sourcedValue(cfg, config.KeyDefaultOrg, cfg.DefaultOrg))
+	fmt.Fprintf(out, "Project:      %s\n", sourcedValue(cfg, config.KeyDefaultProject, cfg.DefaultProject))
+	return nil
+}
+
+// valueSource describes where a resolved setting came from
+func valueSource(cfg *config.Config, key string) string {
+	if source, ok := cfg.Sources[key]; ok {
+		return "from " + source
+	}
+	return "default"
+}
+
+// sourcedValue formats an optional setting together with its source
+func sourcedValue(cfg *config.Config, key, value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return fmt.Sprintf("%s (%s)", value, valueSource(cfg, key))
+}
+
+// promptToken reads a token from the terminal without echoing it, or a single
+// line from stdin when it is not a terminal
+func promptToken(cmd *cobra.Command) (string, error) {
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Paste your Sentry API token (created under Settings > Auth Tokens): ")
+		token, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		return string(token), nil
+	}
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && line == "" {
+		return "", NewInvalidInputError("no API token provided on stdin")
+	}
+	return line, nil
+}
+
+// validateToken checks the token by listing the organizations it can access
+func validateToken(cmd *cobra.Command, cfg *config.Config, token, sentryURL, profile string) error {
+	if sentryURL == "" {
+		sentryURL, _, _ = cfg.Get(config.KeySentryURL, profile)
+	}
+	if sentryURL == "" {
+		sentryURL, _, _ = cfg.Get(config.KeySentryURL, "")
+	}
+	if sentryURL == "" {
+		sentryURL = os.Getenv("SENTRY_URL")
+	}
+
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: token, SentryURL: sentryURL})
+	if err != nil {
+		return err
+	}
+
+	orgs, _, err := api.NewOrganizationsAPI(c).ListOrganizations(cmd.Context(), nil)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			return NewAuthError(fmt.Sprintf("token was rejected by Sentry (status %d)", apiErr.StatusCode))
+		}
+		return fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	slugs := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		slugs = append(slugs, org.Slug)
+	}
+	fmt.Fprintf(os.Stderr, "Token is valid, access to %d organization(s): %s\n", len(orgs), strings.Join(slugs, ", "))
+	return nil
+}
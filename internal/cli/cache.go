@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"sentire/internal/client"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the response cache",
+	Long:  "Inspect and clear the on-disk response cache (~/.cache/sentire). The cache is off by default; enable it with 'sentire config set cache true'.",
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache usage",
+	Long:  "Show the cache directory, the number of cached responses, how many of them are still fresh, and their total size",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached responses",
+	Long:  "Remove all cached responses from the cache directory",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	dir, err := client.DefaultCacheDir()
+	if err != nil {
+		return err
+	}
+
+	stats, err := client.NewCache(dir).Stats()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Directory: %s\n", stats.Dir)
+	fmt.Fprintf(out, "Entries:   %d (%d fresh)\n", stats.Entries, stats.Fresh)
+	fmt.Fprintf(out, "Size:      %s\n", formatBytes(stats.Size))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	dir, err := client.DefaultCacheDir()
+	if err != nil {
+		return err
+	}
+
+	removed, err := client.NewCache(dir).Clear()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached responses\n", removed)
+	return nil
+}
+
+// formatBytes renders a size in bytes using binary units
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
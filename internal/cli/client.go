@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sentire/internal/client"
+	"sentire/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// newClient creates an API client from the loaded configuration, applying
+// any global flag overrides
+func newClient(cmd *cobra.Command) (*client.Client, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := client.NewClientWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// --timeout bounds the whole command, so a single request may use all
+	// of it rather than being cut short by the per-request limit
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		c.HTTPClient.Timeout = 0
+	}
+
+	if cmd.Flags().Changed("max-retries") {
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+		if maxRetries < 0 {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid --max-retries: %d (must be >= 0)", maxRetries))
+		}
+		c.Retry.MaxRetries = maxRetries
+	}
+
+	if cmd.Flags().Changed("concurrency") {
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		if concurrency < 1 {
+			return nil, NewInvalidInputError(fmt.Sprintf("invalid --concurrency: %d (must be >= 1)", concurrency))
+		}
+		c.Concurrency = concurrency
+	}
+
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		c.Verbose = os.Stderr
+	}
+
+	if noCache, _ := cmd.Flags().GetBool("no-cache"); cfg.Cache && !noCache {
+		dir, err := client.DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		c.Cache = client.NewCache(dir)
+		c.Cache.Refresh, _ = cmd.Flags().GetBool("refresh")
+	}
+
+	return c, nil
+}
+
+// loadConfig loads the configuration for the --profile flag and applies the
+// --url override
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	profile, _ := cmd.Flags().GetString("profile")
+	cfg, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: profile})
+	if err != nil {
+		return nil, err
+	}
+	if err := applyURLFlag(cmd, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyURLFlag applies the --url override to cfg. Without it, the warnings
+// raised while resolving the configured URL are printed instead.
+func applyURLFlag(cmd *cobra.Command, cfg *config.Config) error {
+	if sentryURL, _ := cmd.Flags().GetString("url"); sentryURL != "" {
+		if err := validateSentryURL(sentryURL); err != nil {
+			return err
+		}
+		cfg.SentryURL = sentryURL
+		cfg.SetSource(config.KeySentryURL, "--url flag")
+		return nil
+	}
+
+	for _, warning := range cfg.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return nil
+}
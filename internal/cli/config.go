@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"fmt"
+	"sentire/internal/config"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage sentire configuration",
+	Long:  "Read and write settings in the sentire config file (~/.config/sentire/config.json). Use the global --profile flag to manage the settings of a named profile.",
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long:  "Set a configuration value. Keys: " + strings.Join(config.Keys, ", "),
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a configuration value",
+	Long:  "Print a configuration value as stored in the config file. Keys: " + strings.Join(config.Keys, ", "),
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long:  "Remove a configuration value from the config file. Keys: " + strings.Join(config.Keys, ", "),
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUnset,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration values",
+	Long:  "List all values stored in the config file as key=value lines. API tokens are masked unless --show-token is given.",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file path",
+	Long:  "Print the location of the sentire config file",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigPath,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configPathCmd)
+
+	// Flags for list command
+	configListCmd.Flags().Bool("show-token", false, "Show API tokens in full")
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	profile, _ := cmd.Flags().GetString("profile")
+
+	cfg, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if err := validateConfigValue(cfg, key, value); err != nil {
+		return err
+	}
+	if err := cfg.Set(key, value, profile); err != nil {
+		return err
+	}
+
+	return config.SaveConfig(cfg)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	profile, _ := cmd.Flags().GetString("profile")
+
+	cfg, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	value, ok, err := cfg.Get(key, profile)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s is not set", key)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	profile, _ := cmd.Flags().GetString("profile")
+
+	cfg, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Unset(key, profile); err != nil {
+		return err
+	}
+
+	return config.SaveConfig(cfg)
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	profile, _ := cmd.Flags().GetString("profile")
+	showToken, _ := cmd.Flags().GetBool("show-token")
+
+	cfg, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	printSettings := func(prefix, profile string) {
+		for _, key := range config.Keys {
+			if profile != "" && !config.IsProfileKey(key) {
+				continue
+			}
+			value, ok, _ := cfg.Get(key, profile)
+			if !ok {
+				continue
+			}
+			if key == config.KeySentryAPIToken && !showToken {
+				value = maskToken(value)
+			}
+			fmt.Fprintf(w, "%s%s=%s\n", prefix, key, value)
+		}
+	}
+
+	if profile != "" {
+		if _, ok := cfg.Profiles[profile]; !ok {
+			return NewInvalidInputError(fmt.Sprintf("profile %q not found", profile))
+		}
+		printSettings("", profile)
+		return nil
+	}
+
+	printSettings("", "")
+	for _, name := range cfg.ProfileNames() {
+		printSettings("profiles."+name+".", name)
+	}
+	return nil
+}
+
+func runConfigPath(cmd *cobra.Command, args []string) error {
+	path, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), path)
+	return nil
+}
+
+// validateConfigValue checks a value before it is written to the config file
+func validateConfigValue(cfg *config.Config, key, value string) error {
+	switch key {
+	case config.KeySentryURL:
+		return validateSentryURL(value)
+	case config.KeyDefaultOrg:
+		return validateOrgSlug(value)
+	case config.KeyDefaultProject:
+		return validateProjectSlug(value)
+	case config.KeyDefaultProfile:
+		if _, ok := cfg.Profiles[value]; !ok {
+			return NewInvalidInputError(fmt.Sprintf("profile %q not found (create it first with --profile %s)", value, value))
+		}
+	case config.KeySentryAPIToken, config.KeyCredentialHelper:
+		if strings.TrimSpace(value) == "" {
+			return NewInvalidInputError(fmt.Sprintf("%s cannot be empty", key))
+		}
+	}
+	return nil
+}
+
+// maskToken hides all but the last four characters of an API token
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return strings.Repeat("*", 8) + token[len(token)-4:]
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"sentire/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// argDefaultKeys maps positional argument names that can fall back to a
+// configured default to the config key that provides it
+var argDefaultKeys = map[string]string{
+	"organization": config.KeyDefaultOrg,
+	"project":      config.KeyDefaultProject,
+}
+
+// argDefaultEnv names the environment variable documented for each default
+var argDefaultEnv = map[string]string{
+	"organization": "SENTRY_ORG",
+	"project":      "SENTRY_PROJECT",
+}
+
+// resolveArgs fills in omitted <organization> and <project> arguments from
+// the configured defaults. Leading slots are filled first, so with
+// default_org set "list-project my-project" resolves the organization and
+// treats the single argument as the project.
+func resolveArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	slots := parseArgs(cmd.Use)
+	missing := len(slots) - len(args)
+	if missing <= 0 {
+		return args, nil
+	}
+
+	cfg, err := loadDefaults(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, 0, len(slots))
+	next := 0
+	for _, slot := range slots {
+		if missing > 0 {
+			if value := argDefault(cfg, slot.Name); value != "" {
+				resolved = append(resolved, value)
+				missing--
+				continue
+			}
+		}
+		if next >= len(args) {
+			return nil, missingArgError(slot.Name)
+		}
+		resolved = append(resolved, args[next])
+		next++
+	}
+	return resolved, nil
+}
+
+// missingArgError reports a required argument that was neither given nor
+// resolvable from a default
+func missingArgError(name string) error {
+	key, ok := argDefaultKeys[name]
+	if !ok {
+		return NewInvalidInputError(fmt.Sprintf("missing required argument <%s>", name))
+	}
+	return NewInvalidInputError(fmt.Sprintf("missing required argument <%s> (pass it or set %s in config or %s)", name, key, argDefaultEnv[name]))
+}
+
+// loadDefaults loads the configuration used to resolve argument defaults,
+// honoring the --profile flag. No API token is required.
+func loadDefaults(cmd *cobra.Command) (*config.Config, error) {
+	profile, _ := cmd.Flags().GetString("profile")
+	return config.ResolveConfig(config.LoadOptions{Profile: profile})
+}
+
+// argDefault returns the configured default for the named argument, if any
+func argDefault(cfg *config.Config, name string) string {
+	switch argDefaultKeys[name] {
+	case config.KeyDefaultOrg:
+		return cfg.DefaultOrg
+	case config.KeyDefaultProject:
+		return cfg.DefaultProject
+	}
+	return ""
+}
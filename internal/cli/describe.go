@@ -34,6 +34,7 @@ type commandDescription struct {
 type argDescription struct {
 	Name     string `json:"name"`
 	Required bool   `json:"required"`
+	Default  string `json:"default,omitempty"`
 }
 
 type flagDescription struct {
@@ -44,7 +45,8 @@ type flagDescription struct {
 }
 
 type describeOutput struct {
-	Commands []commandDescription `json:"commands"`
+	Commands    []commandDescription `json:"commands"`
+	GlobalFlags []flagDescription    `json:"global_flags"`
 }
 
 var commandModelRegistry = map[string]reflect.Type{
@@ -52,6 +54,7 @@ var commandModelRegistry = map[string]reflect.Type{
 	"events list-issue":      reflect.TypeOf(models.Event{}),
 	"events list-issues":     reflect.TypeOf(models.Issue{}),
 	"events get-event":       reflect.TypeOf(models.Event{}),
+	"events threads":         reflect.TypeOf(models.Thread{}),
 	"events get-issue":       reflect.TypeOf(models.Issue{}),
 	"events get-issue-event": reflect.TypeOf(models.Event{}),
 	"org list-projects":      reflect.TypeOf(models.Project{}),
@@ -74,7 +77,8 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 	}
 
 	output := describeOutput{
-		Commands: collectCommands(rootCmd, ""),
+		Commands:    collectCommands(rootCmd, ""),
+		GlobalFlags: describeFlags(rootCmd.PersistentFlags()),
 	}
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -130,8 +134,21 @@ func buildDescription(cmd *cobra.Command, fullName string) commandDescription {
 	}
 
 	desc.Args = parseArgs(cmd.Use)
+	applyArgDefaults(cmd, desc.Args)
 
-	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+	desc.Flags = describeFlags(cmd.Flags())
+
+	if modelType, ok := commandModelRegistry[fullName]; ok {
+		desc.OutputFields = extractJSONFields(modelType)
+	}
+
+	return desc
+}
+
+// describeFlags describes the visible flags of a flag set
+func describeFlags(flags *pflag.FlagSet) []flagDescription {
+	var result []flagDescription
+	flags.VisitAll(func(f *pflag.Flag) {
 		if f.Hidden {
 			return
 		}
@@ -143,14 +160,9 @@ func buildDescription(cmd *cobra.Command, fullName string) commandDescription {
 		if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" && f.DefValue != "[]" {
 			fd.Default = f.DefValue
 		}
-		desc.Flags = append(desc.Flags, fd)
+		result = append(result, fd)
 	})
-
-	if modelType, ok := commandModelRegistry[fullName]; ok {
-		desc.OutputFields = extractJSONFields(modelType)
-	}
-
-	return desc
+	return result
 }
 
 func parseArgs(use string) []argDescription {
@@ -172,6 +184,21 @@ func parseArgs(use string) []argDescription {
 	return args
 }
 
+// applyArgDefaults marks arguments as optional when a configured default
+// (such as default_org or SENTRY_ORG) can stand in for them
+func applyArgDefaults(cmd *cobra.Command, args []argDescription) {
+	cfg, err := loadDefaults(cmd)
+	if err != nil {
+		return
+	}
+	for i := range args {
+		if value := argDefault(cfg, args[i].Name); value != "" {
+			args[i].Required = false
+			args[i].Default = value
+		}
+	}
+}
+
 func extractJSONFields(t reflect.Type) []string {
 	var fields []string
 	for i := 0; i < t.NumField(); i++ {
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"sentire/internal/archive"
 	"sentire/internal/cli/formatter"
 	"sentire/internal/client"
 	"sentire/internal/config"
@@ -18,6 +20,8 @@ const (
 	ExitAPI           = 3
 	ExitInvalidInput  = 4
 	ExitInvalidFormat = 4
+	ExitTimeout       = 5
+	ExitInterrupted   = 130
 )
 
 // Error codes for structured error output
@@ -26,6 +30,9 @@ const (
 	CodeAPIError      = "api_error"
 	CodeInvalidInput  = "invalid_input"
 	CodeInvalidFormat = "invalid_format"
+	CodeTimeout       = "timeout"
+	CodeInterrupted   = "interrupted"
+	CodeNotArchived   = "not_archived"
 )
 
 // CLIError represents a structured error with a machine-readable code
@@ -75,6 +82,33 @@ func NewInvalidFormatError(message string) *CLIError {
 	}
 }
 
+// NewTimeoutError creates an error for a command that exceeded --timeout
+func NewTimeoutError(message string) *CLIError {
+	return &CLIError{
+		Message:  message,
+		Code:     CodeTimeout,
+		ExitCode: ExitTimeout,
+	}
+}
+
+// NewInterruptedError creates an error for a command canceled by a signal
+func NewInterruptedError(message string) *CLIError {
+	return &CLIError{
+		Message:  message,
+		Code:     CodeInterrupted,
+		ExitCode: ExitInterrupted,
+	}
+}
+
+// NewNotArchivedError creates an error for data missing from the offline archive
+func NewNotArchivedError(message string) *CLIError {
+	return &CLIError{
+		Message:  message,
+		Code:     CodeNotArchived,
+		ExitCode: ExitAPI,
+	}
+}
+
 // wrapError converts known error types into CLIError
 func wrapError(err error) error {
 	if err == nil {
@@ -87,14 +121,40 @@ func wrapError(err error) error {
 	if errors.As(err, &authErr) {
 		return NewAuthError(authErr.Message)
 	}
+	var keyErr *config.KeyError
+	if errors.As(err, &keyErr) {
+		return NewInvalidInputError(keyErr.Message)
+	}
 	var apiErr *client.APIError
 	if errors.As(err, &apiErr) {
 		return NewAPIError(apiErr.Message)
 	}
+	var archiveErr *archive.NotFoundError
+	if errors.As(err, &archiveErr) {
+		return NewNotArchivedError(archiveErr.Message)
+	}
 	var fmtErr *formatter.FormatError
 	if errors.As(err, &fmtErr) {
 		return NewInvalidFormatError(fmtErr.Message)
 	}
+	var fieldsErr *formatter.FieldsError
+	if errors.As(err, &fieldsErr) {
+		return NewInvalidInputError(fieldsErr.Message)
+	}
+	var jqErr *formatter.JQError
+	if errors.As(err, &jqErr) {
+		return NewInvalidInputError(jqErr.Message)
+	}
+	var templateErr *formatter.TemplateError
+	if errors.As(err, &templateErr) {
+		return NewInvalidInputError(templateErr.Message)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewTimeoutError(fmt.Sprintf("timed out: %v", err))
+	}
+	if errors.Is(err, context.Canceled) {
+		return NewInterruptedError("interrupted")
+	}
 	return err
 }
 
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"sentire/internal/api"
 	"sentire/internal/cli/formatter"
 	"sentire/internal/client"
+	"sentire/pkg/models"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +23,7 @@ var listProjectEventsCmd = &cobra.Command{
 	Use:   "list-project <organization> <project>",
 	Short: "List events for a project",
 	Long:  "Retrieve a list of events for a specific project",
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(0, 2),
 	RunE:  runListProjectEvents,
 }
 
@@ -26,40 +31,51 @@ var listIssueEventsCmd = &cobra.Command{
 	Use:   "list-issue <organization> <issue-id>",
 	Short: "List events for an issue",
 	Long:  "Retrieve a list of events for a specific issue",
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(1, 2),
 	RunE:  runListIssueEvents,
 }
 
 var listIssuesCmd = &cobra.Command{
-	Use:   "list-issues <organization>",
-	Short: "List issues for an organization",
-	Long:  "Retrieve a list of issues for a specific organization",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runListIssues,
+	Use:         "list-issues <organization>",
+	Short:       "List issues for an organization",
+	Long:        "Retrieve a list of issues for a specific organization",
+	Args:        cobra.RangeArgs(0, 1),
+	RunE:        runListIssues,
+	Annotations: map[string]string{offlineAnnotation: "true"},
 }
 
 var getEventCmd = &cobra.Command{
 	Use:   "get-event <organization> <project> <event-id>",
 	Short: "Get a specific event",
 	Long:  "Retrieve details for a specific event in a project",
-	Args:  cobra.ExactArgs(3),
+	Args:  cobra.RangeArgs(1, 3),
 	RunE:  runGetEvent,
 }
 
+var threadsCmd = &cobra.Command{
+	Use:   "threads <organization> <project> <event-id>",
+	Short: "List the threads of an event",
+	Long:  "List the threads of a native or JVM event with their stack traces, the crashed thread first",
+	Args:  cobra.RangeArgs(1, 3),
+	RunE:  runThreads,
+}
+
 var getIssueCmd = &cobra.Command{
-	Use:   "get-issue <organization> <issue-id>",
-	Short: "Get a specific issue",
-	Long:  "Retrieve details for a specific issue",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runGetIssue,
+	Use:         "get-issue <organization> <issue-id>",
+	Short:       "Get a specific issue",
+	Long:        "Retrieve details for a specific issue",
+	Args:        cobra.RangeArgs(1, 2),
+	RunE:        runGetIssue,
+	Annotations: map[string]string{offlineAnnotation: "true"},
 }
 
 var getIssueEventCmd = &cobra.Command{
-	Use:   "get-issue-event <organization> <issue-id> <event-id>",
-	Short: "Get a specific event for an issue",
-	Long:  "Retrieve a specific event associated with an issue. Event ID can be 'latest', 'oldest', 'recommended', or a specific event ID",
-	Args:  cobra.ExactArgs(3),
-	RunE:  runGetIssueEvent,
+	Use:         "get-issue-event <organization> <issue-id> <event-id>",
+	Short:       "Get a specific event for an issue",
+	Long:        "Retrieve a specific event associated with an issue. Event ID can be 'latest', 'oldest', 'recommended', or a specific event ID",
+	Args:        cobra.RangeArgs(2, 3),
+	RunE:        runGetIssueEvent,
+	Annotations: map[string]string{offlineAnnotation: "true"},
 }
 
 func init() {
@@ -69,6 +85,7 @@ func init() {
 	eventsCmd.AddCommand(listIssueEventsCmd)
 	eventsCmd.AddCommand(listIssuesCmd)
 	eventsCmd.AddCommand(getEventCmd)
+	eventsCmd.AddCommand(threadsCmd)
 	eventsCmd.AddCommand(getIssueCmd)
 	eventsCmd.AddCommand(getIssueEventCmd)
 
@@ -78,7 +95,7 @@ func init() {
 	listProjectEventsCmd.Flags().String("end", "", "End time (ISO-8601)")
 	listProjectEventsCmd.Flags().Bool("full", false, "Include full event body")
 	listProjectEventsCmd.Flags().Bool("sample", false, "Return events in pseudo-random order")
-	listProjectEventsCmd.Flags().Bool("all", false, "Fetch all pages")
+	addPaginationFlags(listProjectEventsCmd)
 
 	// Flags for list-issue command
 	listIssueEventsCmd.Flags().String("period", "", "Time period (e.g., '24h', '7d')")
@@ -88,7 +105,7 @@ func init() {
 	listIssueEventsCmd.Flags().Bool("full", false, "Include full event body")
 	listIssueEventsCmd.Flags().Bool("sample", false, "Return events in pseudo-random order")
 	listIssueEventsCmd.Flags().String("query", "", "Search query")
-	listIssueEventsCmd.Flags().Bool("all", false, "Fetch all pages")
+	addPaginationFlags(listIssueEventsCmd)
 
 	// Flags for list-issues command
 	listIssuesCmd.Flags().StringSlice("environment", nil, "Filter by environments")
@@ -99,13 +116,22 @@ func init() {
 	listIssuesCmd.Flags().String("query", "is:unresolved issue.priority:[high,medium]", "Search/filter query")
 	listIssuesCmd.Flags().String("sort", "", "Sort order (date, freq, inbox)")
 	listIssuesCmd.Flags().Int("limit", 0, "Maximum number of results")
-	listIssuesCmd.Flags().Bool("all", false, "Fetch all pages")
+	listIssuesCmd.Flags().Bool("with-event", false, "Also fetch the recommended event of each issue, up to --concurrency at a time")
+	addPaginationFlags(listIssuesCmd)
+
+	// Flags for threads command
+	threadsCmd.Flags().Bool("crashed", false, "Only show the crashed thread")
+	threadsCmd.Flags().String("name", "", "Only show threads whose name contains this text")
 
 	// Flags for get-issue-event command
 	getIssueEventCmd.Flags().StringSlice("environment", nil, "Filter by environments")
 }
 
 func runListProjectEvents(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, projectSlug := args[0], args[1]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -115,7 +141,7 @@ func runListProjectEvents(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
@@ -139,35 +165,19 @@ func runListProjectEvents(cmd *cobra.Command, args []string) error {
 		opts.Sample = true
 	}
 
-	fetchAll, _ := cmd.Flags().GetBool("all")
-
-	var allEvents []interface{}
-	cursor := ""
-
-	for {
-		if cursor != "" {
-			opts.Cursor = cursor
-		}
-
-		events, pagination, err := eventsAPI.ListProjectEvents(orgSlug, projectSlug, opts)
-		if err != nil {
-			return err
-		}
-
-		for _, event := range events {
-			allEvents = append(allEvents, event)
-		}
-
-		if !fetchAll || pagination == nil || !pagination.HasNext {
-			break
-		}
-		cursor = pagination.NextCursor
+	pager, err := newPaginator(cmd)
+	if err != nil {
+		return err
 	}
 
-	return formatter.Output(cmd, allEvents)
+	return outputPages(cmd, eventsAPI.IterProjectEvents(cmd.Context(), orgSlug, projectSlug, opts, pager), pager)
 }
 
 func runListIssueEvents(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, issueID := args[0], args[1]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -177,7 +187,7 @@ func runListIssueEvents(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
@@ -207,48 +217,41 @@ func runListIssueEvents(cmd *cobra.Command, args []string) error {
 		opts.Query = query
 	}
 
-	fetchAll, _ := cmd.Flags().GetBool("all")
-
-	var allEvents []interface{}
-	cursor := ""
-
-	for {
-		if cursor != "" {
-			opts.Cursor = cursor
-		}
-
-		events, pagination, err := eventsAPI.ListIssueEvents(orgSlug, issueID, opts)
-		if err != nil {
-			return err
-		}
-
-		for _, event := range events {
-			allEvents = append(allEvents, event)
-		}
-
-		if !fetchAll || pagination == nil || !pagination.HasNext {
-			break
-		}
-		cursor = pagination.NextCursor
+	pager, err := newPaginator(cmd)
+	if err != nil {
+		return err
 	}
 
-	return formatter.Output(cmd, allEvents)
+	return outputPages(cmd, eventsAPI.IterIssueEvents(cmd.Context(), orgSlug, issueID, opts, pager), pager)
 }
 
 func runListIssues(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug := args[0]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
 		return err
 	}
 
-	c, err := client.NewClient()
+	if isOffline(cmd) {
+		// The archive cannot evaluate Sentry's search, so rather than
+		// silently listing everything, explicit search and page size
+		// flags are refused
+		for _, name := range []string{"query", "environment", "project", "period", "start", "end", "sort", "limit"} {
+			if cmd.Flags().Changed(name) {
+				return NewInvalidInputError(fmt.Sprintf("--%s is not supported with --offline; filter archived issues with --where instead", name))
+			}
+		}
+	}
+
+	source, c, err := newIssueSource(cmd)
 	if err != nil {
 		return err
 	}
 
-	eventsAPI := api.NewEventsAPI(c)
-
 	opts := &api.ListIssuesOptions{}
 	if environments, _ := cmd.Flags().GetStringSlice("environment"); len(environments) > 0 {
 		opts.Environment = environments
@@ -275,35 +278,73 @@ func runListIssues(cmd *cobra.Command, args []string) error {
 		opts.Limit = limit
 	}
 
-	fetchAll, _ := cmd.Flags().GetBool("all")
+	pager, err := newPaginator(cmd)
+	if err != nil {
+		return err
+	}
 
-	var allIssues []interface{}
-	cursor := ""
+	// --where is applied before the events are fetched, so no request is
+	// made for an issue that is left out
+	issues, err := applyWhere(cmd, source.IterIssues(cmd.Context(), orgSlug, opts, pager))
+	if err != nil {
+		return err
+	}
+	if withEvent, _ := cmd.Flags().GetBool("with-event"); withEvent {
+		// An issue whose event cannot be fetched, such as one whose events
+		// were deleted, is still listed, without its event
+		issues = client.FanOut(cmd.Context(), c, issues, func(ctx context.Context, issue models.Issue) (models.Issue, error) {
+			event, err := source.GetIssueEvent(ctx, orgSlug, issue.ID, "recommended", nil)
+			if err != nil {
+				if ctx.Err() != nil {
+					return issue, err
+				}
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch the recommended event of issue %s: %v\n", issue.ShortID, err)
+				return issue, nil
+			}
+			issue.RecommendedEvent = event
+			return issue, nil
+		})
+	}
+
+	return writePages(cmd, issues, pager)
+}
 
-	for {
-		if cursor != "" {
-			opts.Cursor = cursor
-		}
+func runGetEvent(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+	orgSlug, projectSlug, eventID := args[0], args[1], args[2]
 
-		issues, pagination, err := eventsAPI.ListIssues(orgSlug, opts)
-		if err != nil {
-			return err
-		}
+	if err := validateOrgSlug(orgSlug); err != nil {
+		return err
+	}
+	if err := validateProjectSlug(projectSlug); err != nil {
+		return err
+	}
+	if err := validateEventID(eventID); err != nil {
+		return err
+	}
 
-		for _, issue := range issues {
-			allIssues = append(allIssues, issue)
-		}
+	c, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
 
-		if !fetchAll || pagination == nil || !pagination.HasNext {
-			break
-		}
-		cursor = pagination.NextCursor
+	eventsAPI := api.NewEventsAPI(c)
+	event, err := eventsAPI.GetProjectEvent(cmd.Context(), orgSlug, projectSlug, eventID)
+	if err != nil {
+		return err
 	}
 
-	return formatter.Output(cmd, allIssues)
+	return formatter.Output(cmd, event)
 }
 
-func runGetEvent(cmd *cobra.Command, args []string) error {
+func runThreads(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, projectSlug, eventID := args[0], args[1], args[2]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -316,21 +357,38 @@ func runGetEvent(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
 
 	eventsAPI := api.NewEventsAPI(c)
-	event, err := eventsAPI.GetProjectEvent(orgSlug, projectSlug, eventID)
+	event, err := eventsAPI.GetProjectEvent(cmd.Context(), orgSlug, projectSlug, eventID)
 	if err != nil {
 		return err
 	}
 
-	return formatter.Output(cmd, event)
+	crashedOnly, _ := cmd.Flags().GetBool("crashed")
+	name, _ := cmd.Flags().GetString("name")
+	threads := []models.Thread{}
+	for _, thread := range models.CrashedFirst(event.Threads()) {
+		if crashedOnly && !thread.Crashed {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(thread.Name), strings.ToLower(name)) {
+			continue
+		}
+		threads = append(threads, thread)
+	}
+
+	return formatter.Output(cmd, threads)
 }
 
 func runGetIssue(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, issueID := args[0], args[1]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -340,13 +398,12 @@ func runGetIssue(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	source, _, err := newIssueSource(cmd)
 	if err != nil {
 		return err
 	}
 
-	eventsAPI := api.NewEventsAPI(c)
-	issue, err := eventsAPI.GetIssue(orgSlug, issueID)
+	issue, err := source.GetIssue(cmd.Context(), orgSlug, issueID)
 	if err != nil {
 		return err
 	}
@@ -355,6 +412,10 @@ func runGetIssue(cmd *cobra.Command, args []string) error {
 }
 
 func runGetIssueEvent(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, issueID, eventID := args[0], args[1], args[2]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -367,19 +428,17 @@ func runGetIssueEvent(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	source, _, err := newIssueSource(cmd)
 	if err != nil {
 		return err
 	}
 
-	eventsAPI := api.NewEventsAPI(c)
-
 	opts := &api.GetIssueEventOptions{}
 	if environments, _ := cmd.Flags().GetStringSlice("environment"); len(environments) > 0 {
 		opts.Environment = environments
 	}
 
-	event, err := eventsAPI.GetIssueEvent(orgSlug, issueID, eventID, opts)
+	event, err := source.GetIssueEvent(cmd.Context(), orgSlug, issueID, eventID, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,123 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// ColorMode is the value of the --color flag
+type ColorMode string
+
+const (
+	// ColorAuto colors output written to a terminal, unless NO_COLOR is set
+	ColorAuto ColorMode = "auto"
+	// ColorAlways colors output even when it is piped or redirected
+	ColorAlways ColorMode = "always"
+	// ColorNever never colors output
+	ColorNever ColorMode = "never"
+)
+
+// ParseColorMode validates a --color value; an empty value means auto
+func ParseColorMode(s string) (ColorMode, error) {
+	switch mode := ColorMode(s); mode {
+	case "":
+		return ColorAuto, nil
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid --color: %s (use auto, always or never)", s)
+}
+
+// styles colors the parts of the output that carry meaning, such as issue
+// levels and in-app frames. The zero value writes everything uncolored.
+type styles struct {
+	enabled bool
+}
+
+// newStyles returns the styles for output to writer under the color mode.
+// In auto mode, color is used only for terminals and only when NO_COLOR is
+// unset or empty (https://no-color.org); always overrides both.
+func newStyles(writer io.Writer, mode ColorMode) styles {
+	switch mode {
+	case ColorAlways:
+		return styles{enabled: true}
+	case ColorNever:
+		return styles{}
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return styles{}
+	}
+	file, ok := writer.(*os.File)
+	return styles{enabled: ok && term.IsTerminal(int(file.Fd()))}
+}
+
+// paint applies the attributes to s when color is enabled
+func (s styles) paint(text string, attrs ...color.Attribute) string {
+	if !s.enabled || text == "" {
+		return text
+	}
+	c := color.New(attrs...)
+	c.EnableColor()
+	return c.Sprint(text)
+}
+
+// level colors an issue, event or breadcrumb level by severity
+func (s styles) level(level string) string {
+	switch level {
+	case "fatal":
+		return s.paint(level, color.FgRed, color.Bold)
+	case "error":
+		return s.paint(level, color.FgRed)
+	case "warning":
+		return s.paint(level, color.FgYellow)
+	case "info":
+		return s.paint(level, color.FgBlue)
+	case "debug":
+		return s.paint(level, color.FgHiBlack)
+	}
+	return level
+}
+
+// status colors an issue status: unresolved issues stand out, resolved ones
+// are green and ignored ones fade
+func (s styles) status(status string) string {
+	switch status {
+	case "unresolved":
+		return s.paint(status, color.FgYellow)
+	case "resolved":
+		return s.paint(status, color.FgGreen)
+	case "ignored", "muted", "archived":
+		return s.paint(status, color.FgHiBlack)
+	}
+	return status
+}
+
+// frame highlights in-app stack frames and fades system frames
+func (s styles) frame(text string, inApp bool) string {
+	if inApp {
+		return s.paint(text, color.Bold)
+	}
+	return s.paint(text, color.FgHiBlack)
+}
+
+// heading emphasizes a section heading
+func (s styles) heading(text string) string {
+	return s.paint(text, color.Bold)
+}
+
+// namedColors are the colors available to the template color helper
+var namedColors = map[string][]color.Attribute{
+	"bold":    {color.Bold},
+	"red":     {color.FgRed},
+	"green":   {color.FgGreen},
+	"yellow":  {color.FgYellow},
+	"blue":    {color.FgBlue},
+	"magenta": {color.FgMagenta},
+	"cyan":    {color.FgCyan},
+	"gray":    {color.FgHiBlack},
+	"grey":    {color.FgHiBlack},
+}
@@ -0,0 +1,322 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sentire/internal/filter"
+	"sentire/pkg/models"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CSVFormatter outputs data as comma- or tab-separated values, one row per
+// record with a header row naming the columns. Each model has a fixed set
+// of columns; --fields replaces them with the selected fields.
+type CSVFormatter struct {
+	writer   *csv.Writer
+	header   []string
+	itemType reflect.Type
+}
+
+// NewCSVFormatter creates a new CSV formatter; comma is ',' for CSV and
+// '\t' for TSV
+func NewCSVFormatter(writer io.Writer, comma rune) *CSVFormatter {
+	w := csv.NewWriter(writer)
+	w.Comma = comma
+	return &CSVFormatter{writer: w}
+}
+
+// csvColumn is a column of the CSV output of a model
+type csvColumn[T any] struct {
+	name  string
+	value func(T) string
+}
+
+func csvHeader[T any](columns []csvColumn[T]) []string {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	return header
+}
+
+func csvRow[T any](columns []csvColumn[T], item T) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		row[i] = col.value(item)
+	}
+	return row
+}
+
+var issueCSVColumns = []csvColumn[models.Issue]{
+	{"id", func(i models.Issue) string { return i.ID }},
+	{"shortId", func(i models.Issue) string { return i.ShortID }},
+	{"title", func(i models.Issue) string { return i.Title }},
+	{"level", func(i models.Issue) string { return i.Level }},
+	{"status", func(i models.Issue) string { return i.Status }},
+	{"priority", func(i models.Issue) string { return i.Priority }},
+	{"count", func(i models.Issue) string { return i.Count }},
+	{"userCount", func(i models.Issue) string { return strconv.Itoa(i.UserCount) }},
+	{"firstSeen", func(i models.Issue) string { return csvTime(i.FirstSeen) }},
+	{"lastSeen", func(i models.Issue) string { return csvTime(i.LastSeen) }},
+	{"project", func(i models.Issue) string { return i.Project.Slug }},
+	{"culprit", func(i models.Issue) string { return i.Culprit }},
+	{"assignedTo", func(i models.Issue) string {
+		if i.AssignedTo == nil {
+			return ""
+		}
+		return i.AssignedTo.Name
+	}},
+	{"permalink", func(i models.Issue) string { return i.Permalink }},
+}
+
+var eventCSVColumns = []csvColumn[models.Event]{
+	{"eventID", func(e models.Event) string { return e.EventID }},
+	{"groupID", func(e models.Event) string { return e.GroupID }},
+	{"title", func(e models.Event) string { return e.Title }},
+	{"message", func(e models.Event) string { return e.Message }},
+	{"type", func(e models.Event) string { return e.Type }},
+	{"platform", func(e models.Event) string { return e.Platform }},
+	{"projectID", func(e models.Event) string { return e.ProjectID }},
+	{"environment", func(e models.Event) string { return e.Environment }},
+	{"release", func(e models.Event) string {
+		if e.Release == nil {
+			return ""
+		}
+		return e.Release.Version
+	}},
+	{"culprit", func(e models.Event) string { return e.Culprit }},
+	{"location", func(e models.Event) string { return e.Location }},
+	{"dateCreated", func(e models.Event) string { return csvTime(e.DateCreated) }},
+}
+
+var projectCSVColumns = []csvColumn[models.Project]{
+	{"id", func(p models.Project) string { return p.ID }},
+	{"slug", func(p models.Project) string { return p.Slug }},
+	{"name", func(p models.Project) string { return p.Name }},
+	{"platform", func(p models.Project) string { return p.Platform }},
+	{"organization", func(p models.Project) string { return p.Organization.Slug }},
+	{"status", func(p models.Project) string { return p.Status }},
+	{"isPublic", func(p models.Project) string { return strconv.FormatBool(p.IsPublic) }},
+	{"dateCreated", func(p models.Project) string { return csvTime(p.DateCreated) }},
+}
+
+var threadCSVColumns = []csvColumn[models.Thread]{
+	{"id", func(t models.Thread) string { return formatFieldValue(t.ID) }},
+	{"name", func(t models.Thread) string { return t.Name }},
+	{"state", func(t models.Thread) string { return t.State }},
+	{"crashed", func(t models.Thread) string { return strconv.FormatBool(t.Crashed) }},
+	{"current", func(t models.Thread) string { return strconv.FormatBool(t.Current) }},
+	{"frames", func(t models.Thread) string { return strconv.Itoa(len(threadFrames(t))) }},
+	{"topFrame", func(t models.Thread) string {
+		frames := threadFrames(t)
+		if len(frames) == 0 {
+			return ""
+		}
+		return frameSummary(frames[len(frames)-1])
+	}},
+}
+
+var statsCSVHeader = []string{"start", "end", "project", "category", "outcome", "quantity"}
+
+// statsCSVRows flattens organization stats to one row per project,
+// category and outcome
+func statsCSVRows(stats *models.OrganizationStats) [][]string {
+	var rows [][]string
+	for _, project := range stats.Projects {
+		name := project.Slug
+		if name == "" {
+			name = fmt.Sprint(project.ID)
+		}
+		for _, category := range project.Stats {
+			o := category.Outcomes
+			outcomes := []struct {
+				name     string
+				quantity int64
+			}{
+				{"accepted", o.Accepted},
+				{"filtered", o.Filtered},
+				{"rate_limited", o.RateLimited},
+				{"invalid", o.Invalid},
+				{"abuse", o.Abuse},
+				{"client_discard", o.ClientDiscard},
+				{"cardinality_limited", o.CardinalityLimited},
+			}
+			for _, outcome := range outcomes {
+				rows = append(rows, []string{
+					csvTime(stats.Start),
+					csvTime(stats.End),
+					name,
+					category.Category,
+					outcome.name,
+					strconv.FormatInt(outcome.quantity, 10),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// csvTypeHeader returns the header of the fixed columns of a model type, or
+// nil when the columns depend on the records
+func csvTypeHeader(t reflect.Type) []string {
+	switch t {
+	case reflect.TypeOf(models.Event{}):
+		return csvHeader(eventCSVColumns)
+	case reflect.TypeOf(models.Issue{}):
+		return csvHeader(issueCSVColumns)
+	case reflect.TypeOf(models.Project{}):
+		return csvHeader(projectCSVColumns)
+	case reflect.TypeOf(models.Thread{}):
+		return csvHeader(threadCSVColumns)
+	}
+	return nil
+}
+
+// csvRecord returns the header and rows of one record. Records without a
+// fixed set of columns get one column per top-level JSON field; once a
+// header has been written, their values are mapped onto its columns so
+// that every row lines up with it.
+func csvRecord(item interface{}, header []string) ([]string, [][]string) {
+	switch v := item.(type) {
+	case models.Event:
+		return csvHeader(eventCSVColumns), [][]string{csvRow(eventCSVColumns, v)}
+	case *models.Event:
+		return csvRecord(*v, header)
+	case models.Issue:
+		return csvHeader(issueCSVColumns), [][]string{csvRow(issueCSVColumns, v)}
+	case *models.Issue:
+		return csvRecord(*v, header)
+	case models.Project:
+		return csvHeader(projectCSVColumns), [][]string{csvRow(projectCSVColumns, v)}
+	case *models.Project:
+		return csvRecord(*v, header)
+	case models.Thread:
+		return csvHeader(threadCSVColumns), [][]string{csvRow(threadCSVColumns, v)}
+	case *models.OrganizationStats:
+		return statsCSVHeader, statsCSVRows(v)
+	case selection:
+		return v.names(), [][]string{v.strings()}
+	}
+
+	m, ok := filter.ToJSONValue(item).(map[string]interface{})
+	if !ok {
+		return []string{"value"}, [][]string{{formatFieldValue(filter.ToJSONValue(item))}}
+	}
+	if header == nil {
+		header = make([]string, 0, len(m))
+		for k := range m {
+			header = append(header, k)
+		}
+		sort.Strings(header)
+	}
+	row := make([]string, len(header))
+	for i, k := range header {
+		row[i] = formatFieldValue(m[k])
+	}
+	return header, [][]string{row}
+}
+
+// FormatEvent formats a single event as CSV
+func (f *CSVFormatter) FormatEvent(event *models.Event) error {
+	return f.FormatGeneric(event)
+}
+
+// FormatEvents formats multiple events as CSV
+func (f *CSVFormatter) FormatEvents(events []models.Event) error {
+	return f.FormatGeneric(events)
+}
+
+// FormatIssue formats a single issue as CSV
+func (f *CSVFormatter) FormatIssue(issue *models.Issue) error {
+	return f.FormatGeneric(issue)
+}
+
+// FormatIssues formats multiple issues as CSV
+func (f *CSVFormatter) FormatIssues(issues []models.Issue) error {
+	return f.FormatGeneric(issues)
+}
+
+// FormatProject formats a single project as CSV
+func (f *CSVFormatter) FormatProject(project *models.Project) error {
+	return f.FormatGeneric(project)
+}
+
+// FormatProjects formats multiple projects as CSV
+func (f *CSVFormatter) FormatProjects(projects []models.Project) error {
+	return f.FormatGeneric(projects)
+}
+
+// FormatOrgStats formats organization stats as CSV, one row per project,
+// category and outcome
+func (f *CSVFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
+	return f.FormatGeneric(stats)
+}
+
+// FormatGeneric formats a record or a list of records as CSV. An empty list
+// of a model type is written as its header row alone.
+func (f *CSVFormatter) FormatGeneric(data interface{}) error {
+	f.header = nil
+	f.itemType = nil
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice {
+		f.itemType = v.Type().Elem()
+		for i := 0; i < v.Len(); i++ {
+			if err := f.Record(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	} else if err := f.Record(data); err != nil {
+		return err
+	}
+	return f.End()
+}
+
+// SetItemType sets the type of the records of a streamed list, whose
+// header is written by End when the list turns out to be empty
+func (f *CSVFormatter) SetItemType(t reflect.Type) {
+	f.itemType = t
+}
+
+// Begin starts a streamed list
+func (f *CSVFormatter) Begin() error {
+	f.header = nil
+	return nil
+}
+
+// Record writes the rows of one record. The header row is written before
+// the first record, with the columns of its type.
+func (f *CSVFormatter) Record(item interface{}) error {
+	header, rows := csvRecord(item, f.header)
+	if f.header == nil {
+		if err := f.writer.Write(header); err != nil {
+			return err
+		}
+		f.header = header
+	}
+	return f.writer.WriteAll(rows)
+}
+
+// End flushes the output. An empty list of a model type gets its header
+// row.
+func (f *CSVFormatter) End() error {
+	if f.header == nil {
+		if header := csvTypeHeader(f.itemType); header != nil {
+			if err := f.writer.Write(header); err != nil {
+				return err
+			}
+		}
+	}
+	f.writer.Flush()
+	return f.writer.Error()
+}
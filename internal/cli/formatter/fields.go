@@ -1,62 +1,341 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"sentire/internal/filter"
+	"sentire/pkg/models"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-// filterFields filters data to only include the specified fields.
-// If fields is nil or empty, data is returned unchanged.
-func filterFields(data interface{}, fields []string) interface{} {
-	if len(fields) == 0 {
-		return data
+// FieldsError reports an invalid --fields value
+type FieldsError struct {
+	Message string
+}
+
+func (e *FieldsError) Error() string {
+	return e.Message
+}
+
+// field is one --fields entry: a path into the JSON form of a record,
+// optionally renamed with path:name
+type field struct {
+	path  string
+	name  string
+	steps []fieldStep
+}
+
+// fieldStep is one step of a field path
+type fieldStep struct {
+	key      string // object key, or "*" for every key
+	index    int    // list index, when isIndex is set
+	isIndex  bool
+	allItems bool // [*]
+}
+
+// parseFields parses a comma-separated --fields value such as
+// "id,project.slug:project,tags[*].key,contexts.*.name"
+func parseFields(spec string) ([]field, error) {
+	var fields []field
+	names := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		path, name := entry, entry
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			path, name = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+			if path == "" || name == "" {
+				return nil, &FieldsError{Message: fmt.Sprintf("invalid field %q: expected path:name", entry)}
+			}
+		}
+
+		steps, err := parseFieldPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if names[name] {
+			return nil, &FieldsError{Message: fmt.Sprintf("duplicate field name %q in --fields", name)}
+		}
+		names[name] = true
+		fields = append(fields, field{path: path, name: name, steps: steps})
 	}
+	return fields, nil
+}
 
-	v := reflect.ValueOf(data)
+// parseFieldPath parses a dotted path with [n] and [*] list selectors
+func parseFieldPath(path string) ([]fieldStep, error) {
+	invalid := func(reason string) error {
+		return &FieldsError{Message: fmt.Sprintf("invalid field %q: %s", path, reason)}
+	}
 
-	// Handle pointers
-	if v.Kind() == reflect.Ptr {
-		if v.IsNil() {
-			return data
+	var steps []fieldStep
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && len(steps) == 0 || key == "" && rest == "" {
+			return nil, invalid("empty field name")
 		}
-		v = v.Elem()
+		if key != "" {
+			steps = append(steps, fieldStep{key: key})
+		}
+
+		for rest != "" {
+			selector, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, invalid("missing ]")
+			}
+			if selector == "*" {
+				steps = append(steps, fieldStep{allItems: true})
+			} else {
+				n, err := strconv.Atoi(selector)
+				if err != nil || n < 0 {
+					return nil, invalid(fmt.Sprintf("list index must be a number or *, got %q", selector))
+				}
+				steps = append(steps, fieldStep{index: n, isIndex: true})
+			}
+
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, invalid(fmt.Sprintf("unexpected %q", after))
+			}
+			rest = after[1:]
+		}
+	}
+	return steps, nil
+}
+
+// selection is a record reduced to the selected fields, in --fields order
+type selection struct {
+	fields []field
+	values []interface{}
+	found  []bool
+}
+
+// selectFields reduces record to the given fields
+func selectFields(record interface{}, fields []field) selection {
+	doc := filter.ToJSONValue(record)
+	sel := selection{
+		fields: fields,
+		values: make([]interface{}, len(fields)),
+		found:  make([]bool, len(fields)),
 	}
+	for i, f := range fields {
+		sel.values[i], sel.found[i] = lookupField(doc, f.steps)
+	}
+	return sel
+}
 
-	// Handle slices: filter each element
+// selectData applies selectFields to a record or to each record of a list
+func selectData(data interface{}, fields []field) interface{} {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
 	if v.Kind() == reflect.Slice {
 		result := make([]interface{}, v.Len())
 		for i := 0; i < v.Len(); i++ {
-			result[i] = filterFields(v.Index(i).Interface(), fields)
+			result[i] = selectFields(v.Index(i).Interface(), fields)
 		}
 		return result
 	}
+	return selectFields(data, fields)
+}
 
-	// For structs and maps, marshal to map then filter keys
-	return filterMap(data, fields)
+// lookupField follows steps through a JSON value. A key applied to a list
+// is applied to each of its elements, so exception.values.type selects the
+// type of every exception; selections that fan out return a flat list.
+func lookupField(v interface{}, steps []fieldStep) (interface{}, bool) {
+	values, many := lookupSteps(v, steps)
+	if many {
+		if values == nil {
+			values = []interface{}{}
+		}
+		return values, true
+	}
+	if len(values) == 1 {
+		return values[0], true
+	}
+	return nil, false
 }
 
-// filterMap marshals data to a map and keeps only requested fields
-func filterMap(data interface{}, fields []string) interface{} {
-	b, err := json.Marshal(data)
-	if err != nil {
-		return data
+func lookupSteps(v interface{}, steps []fieldStep) (values []interface{}, many bool) {
+	if len(steps) == 0 {
+		return []interface{}{v}, false
 	}
+	step, rest := steps[0], steps[1:]
 
-	var m map[string]interface{}
-	if err := json.Unmarshal(b, &m); err != nil {
-		return data
+	// each applies the remaining steps to every element
+	each := func(items []interface{}, steps []fieldStep) ([]interface{}, bool) {
+		var out []interface{}
+		for _, item := range items {
+			sub, _ := lookupSteps(item, steps)
+			out = append(out, sub...)
+		}
+		return out, true
 	}
 
-	keep := make(map[string]bool, len(fields))
-	for _, f := range fields {
-		keep[f] = true
+	switch x := v.(type) {
+	case map[string]interface{}:
+		switch {
+		case step.key == "*":
+			keys := make([]string, 0, len(x))
+			for k := range x {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			items := make([]interface{}, len(keys))
+			for i, k := range keys {
+				items[i] = x[k]
+			}
+			return each(items, rest)
+		case step.key != "":
+			value, ok := x[step.key]
+			if !ok {
+				return nil, false
+			}
+			return lookupSteps(value, rest)
+		}
+
+	case []interface{}:
+		switch {
+		case step.isIndex:
+			if step.index >= len(x) {
+				return nil, false
+			}
+			return lookupSteps(x[step.index], rest)
+		case step.allItems:
+			return each(x, rest)
+		default:
+			return each(x, steps)
+		}
 	}
+	return nil, false
+}
+
+// MarshalJSON writes the selected fields as an object, in --fields order.
+// Fields that are absent from the record are left out.
+func (s selection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for i, f := range s.fields {
+		if !s.found[i] {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
 
-	filtered := make(map[string]interface{}, len(fields))
-	for k, v := range m {
-		if keep[k] {
-			filtered[k] = v
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(s.values[i])
+		if err != nil {
+			return nil, err
 		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// names returns the names of the selected fields
+func (s selection) names() []string {
+	names := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		names[i] = f.name
 	}
-	return filtered
+	return names
+}
+
+// strings returns the selected values formatted for table, text and
+// markdown output
+func (s selection) strings() []string {
+	values := make([]string, len(s.values))
+	for i, v := range s.values {
+		values[i] = formatFieldValue(v)
+	}
+	return values
+}
+
+// formatFieldValue formats a JSON value as a single line of text. Lists of
+// scalars are joined with commas; objects are written as compact JSON.
+func formatFieldValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case []interface{}:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			if _, ok := item.(map[string]interface{}); ok {
+				b, _ := json.Marshal(x)
+				return string(b)
+			}
+			parts[i] = formatFieldValue(item)
+		}
+		return strings.Join(parts, ", ")
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// fieldFormatter reduces every record to the --fields selection before
+// handing it to the formatter of the chosen output format
+type fieldFormatter struct {
+	Formatter
+	fields []field
+}
+
+func (f *fieldFormatter) FormatEvent(event *models.Event) error {
+	return f.FormatGeneric(event)
+}
+
+func (f *fieldFormatter) FormatEvents(events []models.Event) error {
+	return f.FormatGeneric(events)
+}
+
+func (f *fieldFormatter) FormatIssue(issue *models.Issue) error {
+	return f.FormatGeneric(issue)
+}
+
+func (f *fieldFormatter) FormatIssues(issues []models.Issue) error {
+	return f.FormatGeneric(issues)
+}
+
+func (f *fieldFormatter) FormatProject(project *models.Project) error {
+	return f.FormatGeneric(project)
+}
+
+func (f *fieldFormatter) FormatProjects(projects []models.Project) error {
+	return f.FormatGeneric(projects)
+}
+
+func (f *fieldFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
+	return f.FormatGeneric(stats)
+}
+
+func (f *fieldFormatter) FormatGeneric(data interface{}) error {
+	return f.Formatter.FormatGeneric(selectData(data, f.fields))
+}
+
+func (f *fieldFormatter) Record(item interface{}) error {
+	return f.Formatter.Record(selectFields(item, f.fields))
 }
@@ -1,10 +1,11 @@
 package formatter
 
 import (
+	"context"
 	"io"
 	"os"
+	"reflect"
 	"sentire/pkg/models"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -28,6 +29,22 @@ type Formatter interface {
 	FormatProjects(projects []models.Project) error
 	FormatOrgStats(stats *models.OrganizationStats) error
 	FormatGeneric(data interface{}) error
+
+	// Begin, Record and End write a list one record at a time, so long
+	// listings can be output as each page arrives. End must be called once
+	// the records are written, including when the listing stopped early.
+	// Begin writes nothing, so a listing that fails before its first record
+	// can be dropped without calling End.
+	Begin() error
+	Record(item interface{}) error
+	End() error
+}
+
+// ItemTyped is implemented by formatters whose output for an empty list
+// depends on the type of its items, such as CSV writing the header row of
+// the type's columns. SetItemType is called before Begin.
+type ItemTyped interface {
+	SetItemType(t reflect.Type)
 }
 
 // NewFormatter creates a formatter based on the format flag
@@ -37,34 +54,71 @@ func NewFormatter(cmd *cobra.Command, writer io.Writer) (Formatter, error) {
 		writer = os.Stdout
 	}
 
-	var fields []string
-	if fieldsStr, _ := cmd.Flags().GetString("fields"); fieldsStr != "" {
-		for _, f := range strings.Split(fieldsStr, ",") {
-			f = strings.TrimSpace(f)
-			if f != "" {
-				fields = append(fields, f)
-			}
-		}
+	fieldsSpec, _ := cmd.Flags().GetString("fields")
+	fields, err := parseFields(fieldsSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	colorFlag, _ := cmd.Flags().GetString("color")
+	colorMode, err := ParseColorMode(colorFlag)
+	if err != nil {
+		return nil, &FormatError{Message: err.Error()}
 	}
 
+	var formatter Formatter
 	switch format {
 	case "json":
-		return NewJSONFormatter(writer, fields), nil
+		formatter = NewJSONFormatter(writer)
 	case "ndjson":
-		return NewNDJSONFormatter(writer, fields), nil
+		formatter = NewNDJSONFormatter(writer)
+	case "yaml":
+		formatter = NewYAMLFormatter(writer)
 	case "table":
-		return NewTableFormatter(writer), nil
+		formatter = NewTableFormatter(writer, colorMode)
 	case "text":
-		return NewTextFormatter(writer), nil
+		formatter = NewTextFormatter(writer, colorMode)
+	case "trace":
+		vars, _ := cmd.Flags().GetBool("trace-vars")
+		raw, _ := cmd.Flags().GetBool("trace-raw")
+		formatter = NewTraceFormatter(writer, colorMode, vars, raw)
 	case "markdown":
-		return NewMarkdownFormatter(writer), nil
+		formatter = NewMarkdownFormatter(writer)
+	case "html":
+		formatter = NewHTMLFormatter(writer)
+	case "csv":
+		formatter = NewCSVFormatter(writer, ',')
+	case "tsv":
+		formatter = NewCSVFormatter(writer, '\t')
+	case "template":
+		source, err := templateSource(cmd)
+		if err != nil {
+			return nil, err
+		}
+		if formatter, err = NewTemplateFormatter(writer, source, colorMode); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, &FormatError{Message: "unsupported format: " + format}
 	}
+
+	if len(fields) > 0 {
+		formatter = &fieldFormatter{Formatter: formatter, fields: fields}
+	}
+	return formatter, nil
 }
 
-// Output is the main output function that replaces outputJSON
+// Output is the main output function that replaces outputJSON. With --jq,
+// the program's results are written instead of data.
 func Output(cmd *cobra.Command, data interface{}) error {
+	if source, _ := cmd.Flags().GetString("jq"); source != "" {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return outputJQ(ctx, cmd, os.Stdout, source, data)
+	}
+
 	formatter, err := NewFormatter(cmd, nil)
 	if err != nil {
 		return err
@@ -1,6 +1,11 @@
 package formatter
 
-import "time"
+import (
+	"fmt"
+	"sentire/pkg/models"
+	"strings"
+	"time"
+)
 
 // Helper functions shared across formatters
 
@@ -17,3 +22,62 @@ func formatTime(t *time.Time) string {
 	}
 	return t.Format("2006-01-02 15:04:05")
 }
+
+// isInApp reports whether a stack frame is in the application's own code
+func isInApp(frame models.StackFrame) bool {
+	return frame.InApp != nil && *frame.InApp
+}
+
+// threadLabel names a thread with its flags, such as
+// `Thread 2 "worker" (crashed, current)`
+func threadLabel(thread models.Thread) string {
+	label := "Thread"
+	if id := formatFieldValue(thread.ID); id != "" {
+		label += " " + id
+	}
+	if thread.Name != "" {
+		label += fmt.Sprintf(" %q", thread.Name)
+	}
+	var flags []string
+	if thread.Crashed {
+		flags = append(flags, "crashed")
+	}
+	if thread.Current {
+		flags = append(flags, "current")
+	}
+	if len(flags) > 0 {
+		label += " (" + strings.Join(flags, ", ") + ")"
+	}
+	return label
+}
+
+// threadFrames returns the frames of a thread, oldest first
+func threadFrames(thread models.Thread) []models.StackFrame {
+	if thread.Stacktrace == nil {
+		return nil
+	}
+	return thread.Stacktrace.Frames
+}
+
+// frameSummary describes a frame on one line, such as
+// "get_user (app/views.py:42)"
+func frameSummary(frame models.StackFrame) string {
+	location := frame.Filename
+	if location == "" {
+		location = frame.Module
+	}
+	if location == "" {
+		location = frame.Package
+	}
+	if frame.LineNo != nil {
+		location = fmt.Sprintf("%s:%d", location, *frame.LineNo)
+	}
+	function := frame.Function
+	if function == "" {
+		function = "<unknown>"
+	}
+	if location == "" {
+		return function
+	}
+	return fmt.Sprintf("%s (%s)", function, location)
+}
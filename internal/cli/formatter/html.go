@@ -0,0 +1,466 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"reflect"
+	"sentire/internal/filter"
+	"sentire/pkg/models"
+	"sort"
+	"time"
+)
+
+// HTMLFormatter outputs data as a self-contained HTML page, with the styles
+// inline and no external assets, so the output can be saved and attached to
+// a ticket. Events get their stack traces as collapsible frames with the
+// surrounding source lines, their breadcrumbs as a timeline and their tags
+// as a table; issues link to their Sentry permalink.
+type HTMLFormatter struct {
+	writer io.Writer
+
+	records []interface{}
+}
+
+// NewHTMLFormatter creates a new HTML formatter
+func NewHTMLFormatter(writer io.Writer) *HTMLFormatter {
+	return &HTMLFormatter{writer: writer}
+}
+
+// htmlPage is the data the page template is executed with; exactly one of
+// the content fields is set
+type htmlPage struct {
+	Title string
+
+	Event  *htmlEvent
+	Issue  *models.Issue
+	Issues []models.Issue
+	Table  *htmlTable
+}
+
+// htmlTable is a plain table of records
+type htmlTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+// htmlEvent is an event with its exceptions, threads, breadcrumbs and
+// request decoded for display
+type htmlEvent struct {
+	*models.Event
+
+	Exceptions  []htmlException
+	Threads     []htmlThread
+	Breadcrumbs []models.Breadcrumb
+	Request     *models.Request
+}
+
+type htmlException struct {
+	Type    string
+	Value   string
+	Handled string
+	Frames  []htmlFrame
+}
+
+type htmlThread struct {
+	Label   string
+	State   string
+	Crashed bool
+	Frames  []htmlFrame
+}
+
+type htmlFrame struct {
+	Function string
+	Location string
+	InApp    bool
+	Lines    []htmlLine
+	Vars     []htmlVar
+}
+
+type htmlLine struct {
+	No      int
+	Code    string
+	Current bool
+}
+
+type htmlVar struct {
+	Name  string
+	Value string
+}
+
+// newHTMLEvent prepares an event for display. Sentry lists exceptions and
+// frames oldest first; both are shown most recent first. Threads are shown
+// crashed thread first.
+func newHTMLEvent(event *models.Event) *htmlEvent {
+	view := &htmlEvent{Event: event, Breadcrumbs: event.BreadcrumbValues(), Request: event.HTTPRequest()}
+
+	exceptions := event.Exceptions()
+	for i := len(exceptions) - 1; i >= 0; i-- {
+		e := exceptions[i]
+		exception := htmlException{Type: e.Type, Value: e.Value}
+		if e.Mechanism != nil && e.Mechanism.Handled != nil {
+			exception.Handled = "unhandled"
+			if *e.Mechanism.Handled {
+				exception.Handled = "handled"
+			}
+		}
+		if e.Stacktrace != nil {
+			for j := len(e.Stacktrace.Frames) - 1; j >= 0; j-- {
+				exception.Frames = append(exception.Frames, newHTMLFrame(e.Stacktrace.Frames[j]))
+			}
+		}
+		view.Exceptions = append(view.Exceptions, exception)
+	}
+
+	for _, t := range models.CrashedFirst(event.Threads()) {
+		thread := htmlThread{Label: threadLabel(t), State: t.State, Crashed: t.Crashed}
+		frames := threadFrames(t)
+		for j := len(frames) - 1; j >= 0; j-- {
+			thread.Frames = append(thread.Frames, newHTMLFrame(frames[j]))
+		}
+		view.Threads = append(view.Threads, thread)
+	}
+	return view
+}
+
+func newHTMLFrame(f models.StackFrame) htmlFrame {
+	frame := htmlFrame{
+		Function: f.Function,
+		Location: f.Filename,
+		InApp:    isInApp(f),
+	}
+	if frame.Function == "" {
+		frame.Function = "<unknown>"
+	}
+	if frame.Location == "" {
+		frame.Location = f.Module
+	}
+
+	if f.LineNo != nil {
+		frame.Location = fmt.Sprintf("%s:%d", frame.Location, *f.LineNo)
+	}
+	lines, current := f.SourceContext()
+	for i, line := range lines {
+		frame.Lines = append(frame.Lines, htmlLine{No: line.LineNo, Code: line.Code, Current: i == current})
+	}
+
+	names := make([]string, 0, len(f.Vars))
+	for name := range f.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		frame.Vars = append(frame.Vars, htmlVar{Name: name, Value: formatFieldValue(filter.ToJSONValue(f.Vars[name]))})
+	}
+	return frame
+}
+
+// FormatEvent formats a single event as an HTML page
+func (f *HTMLFormatter) FormatEvent(event *models.Event) error {
+	title := event.Title
+	if title == "" {
+		title = "Event " + event.EventID
+	}
+	return f.render(htmlPage{Title: title, Event: newHTMLEvent(event)})
+}
+
+// FormatEvents formats multiple events as an HTML table
+func (f *HTMLFormatter) FormatEvents(events []models.Event) error {
+	table := &htmlTable{Header: csvHeader(eventCSVColumns)}
+	for _, event := range events {
+		table.Rows = append(table.Rows, csvRow(eventCSVColumns, event))
+	}
+	return f.render(htmlPage{Title: "Events", Table: table})
+}
+
+// FormatIssue formats a single issue as an HTML page, including its
+// recommended event when there is one
+func (f *HTMLFormatter) FormatIssue(issue *models.Issue) error {
+	page := htmlPage{Title: issue.Title, Issue: issue}
+	if issue.RecommendedEvent != nil {
+		page.Event = newHTMLEvent(issue.RecommendedEvent)
+	}
+	return f.render(page)
+}
+
+// FormatIssues formats multiple issues as an HTML table
+func (f *HTMLFormatter) FormatIssues(issues []models.Issue) error {
+	if len(issues) == 0 {
+		return f.render(htmlPage{Title: "Issues", Table: &htmlTable{}})
+	}
+	return f.render(htmlPage{Title: "Issues", Issues: issues})
+}
+
+// FormatProject formats a single project as an HTML table
+func (f *HTMLFormatter) FormatProject(project *models.Project) error {
+	return f.FormatProjects([]models.Project{*project})
+}
+
+// FormatProjects formats multiple projects as an HTML table
+func (f *HTMLFormatter) FormatProjects(projects []models.Project) error {
+	table := &htmlTable{Header: csvHeader(projectCSVColumns)}
+	for _, project := range projects {
+		table.Rows = append(table.Rows, csvRow(projectCSVColumns, project))
+	}
+	return f.render(htmlPage{Title: "Projects", Table: table})
+}
+
+// FormatOrgStats formats organization stats as an HTML table, one row per
+// project, category and outcome
+func (f *HTMLFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
+	table := &htmlTable{Header: statsCSVHeader, Rows: statsCSVRows(stats)}
+	return f.render(htmlPage{Title: "Organization Statistics", Table: table})
+}
+
+// FormatGeneric formats any data as an HTML page. Lists of a known model
+// are formatted as that model; other records become a table.
+func (f *HTMLFormatter) FormatGeneric(data interface{}) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		switch item := data.(type) {
+		case *models.Event:
+			return f.FormatEvent(item)
+		case *models.Issue:
+			return f.FormatIssue(item)
+		case *models.Project:
+			return f.FormatProject(item)
+		case *models.OrganizationStats:
+			return f.FormatOrgStats(item)
+		}
+		return f.formatRecords("Details", []interface{}{data})
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	if len(items) > 0 {
+		switch items[0].(type) {
+		case models.Event:
+			if events, ok := sliceOf[models.Event](items); ok {
+				return f.FormatEvents(events)
+			}
+		case models.Issue:
+			if issues, ok := sliceOf[models.Issue](items); ok {
+				return f.FormatIssues(issues)
+			}
+		case models.Project:
+			if projects, ok := sliceOf[models.Project](items); ok {
+				return f.FormatProjects(projects)
+			}
+		case models.Thread:
+			return f.formatRecords("Threads", items)
+		}
+	}
+	return f.formatRecords("Results", items)
+}
+
+// sliceOf converts items to a []T if they are all of type T
+func sliceOf[T any](items []interface{}) ([]T, bool) {
+	typed := make([]T, len(items))
+	for i, item := range items {
+		v, ok := item.(T)
+		if !ok {
+			return nil, false
+		}
+		typed[i] = v
+	}
+	return typed, true
+}
+
+// formatRecords formats records as a table with the columns of the first
+// record, which is how --fields selections and unknown data are shown
+func (f *HTMLFormatter) formatRecords(title string, items []interface{}) error {
+	table := &htmlTable{}
+	for _, item := range items {
+		header, rows := csvRecord(item, table.Header)
+		if table.Header == nil {
+			table.Header = header
+		}
+		table.Rows = append(table.Rows, rows...)
+	}
+	return f.render(htmlPage{Title: title, Table: table})
+}
+
+// Begin starts a streamed list. The page is written by End, once every
+// record is known.
+func (f *HTMLFormatter) Begin() error {
+	f.records = nil
+	return nil
+}
+
+// Record buffers one record of a streamed list
+func (f *HTMLFormatter) Record(item interface{}) error {
+	f.records = append(f.records, item)
+	return nil
+}
+
+// End renders the buffered records
+func (f *HTMLFormatter) End() error {
+	records := f.records
+	f.records = nil
+	return f.FormatGeneric(records)
+}
+
+func (f *HTMLFormatter) render(page htmlPage) error {
+	return htmlTemplate.Execute(f.writer, page)
+}
+
+var htmlTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02 15:04:05 MST")
+	},
+	"json": func(v interface{}) string {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	},
+}).Parse(htmlPageTemplate))
+
+const htmlPageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 14px; color: #2b2233; margin: 2em auto; max-width: 1100px; padding: 0 1em; }
+h1 { font-size: 1.6em; margin-bottom: 0.2em; }
+h2 { font-size: 1.25em; margin-top: 1.8em; border-bottom: 1px solid #e0dce5; padding-bottom: 0.3em; }
+h3 { font-size: 1.05em; margin-bottom: 0.4em; }
+a { color: #6c5fc7; }
+table { border-collapse: collapse; width: 100%; margin: 0.5em 0; }
+th, td { text-align: left; vertical-align: top; padding: 0.35em 0.6em; border-bottom: 1px solid #ebe8ef; }
+th { background: #f7f6f9; font-weight: 600; }
+code, pre { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 12.5px; }
+pre { background: #f7f6f9; padding: 0.8em; overflow-x: auto; }
+.meta { color: #80708f; }
+.badge { display: inline-block; padding: 0.1em 0.5em; border-radius: 3px; font-size: 0.85em; background: #ebe8ef; }
+.level-fatal, .level-error { background: #fbe3e6; color: #c21f3a; }
+.level-warning { background: #fdf3d7; color: #9c6b00; }
+.level-info { background: #e2eefc; color: #2562d4; }
+details.frame { border: 1px solid #e0dce5; border-radius: 4px; margin: 0.4em 0; }
+details.frame summary { cursor: pointer; padding: 0.4em 0.6em; }
+details.frame.system summary { color: #80708f; }
+details.frame .source { margin: 0; padding: 0.4em 0; border-top: 1px solid #e0dce5; }
+.source td { border: 0; padding: 0 0.6em; white-space: pre; font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 12.5px; }
+.source td.lineno { color: #80708f; text-align: right; width: 3em; user-select: none; }
+.source tr.current { background: #f1ecfc; font-weight: 600; }
+.vars { margin: 0; border-top: 1px solid #e0dce5; }
+details.thread { margin: 0.6em 0; }
+details.thread > summary { cursor: pointer; font-weight: 600; padding: 0.3em 0; }
+details.thread.crashed > summary { color: #c21f3a; }
+.timeline td.time { white-space: nowrap; color: #80708f; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- with .Issue}}{{template "issue" .}}{{end}}
+{{- with .Event}}{{template "event" .}}{{end}}
+{{- with .Issues}}{{template "issues" .}}{{end}}
+{{- with .Table}}{{template "table" .}}{{end}}
+</body>
+</html>
+{{define "issues"}}
+<table>
+<tr><th>Issue</th><th>Title</th><th>Level</th><th>Status</th><th>Events</th><th>Users</th><th>Last Seen</th><th>Project</th></tr>
+{{- range .}}
+<tr><td>{{if .Permalink}}<a href="{{.Permalink}}">{{.ShortID}}</a>{{else}}{{.ShortID}}{{end}}</td><td>{{.Title}}{{with .Culprit}}<br><span class="meta">{{.}}</span>{{end}}</td><td><span class="badge level-{{.Level}}">{{.Level}}</span></td><td>{{.Status}}</td><td>{{.Count}}</td><td>{{.UserCount}}</td><td>{{time .LastSeen}}</td><td>{{.Project.Slug}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+{{define "issue"}}
+<p class="meta">{{if .Permalink}}<a href="{{.Permalink}}">{{.ShortID}}</a>{{else}}{{.ShortID}}{{end}} · <span class="badge level-{{.Level}}">{{.Level}}</span> · {{.Status}}</p>
+<table>
+<tr><th>ID</th><td>{{.ID}}</td></tr>
+<tr><th>Project</th><td>{{.Project.Slug}}</td></tr>
+{{- with .Culprit}}<tr><th>Culprit</th><td><code>{{.}}</code></td></tr>{{end}}
+<tr><th>Events</th><td>{{.Count}}</td></tr>
+<tr><th>Users</th><td>{{.UserCount}}</td></tr>
+<tr><th>First Seen</th><td>{{time .FirstSeen}}</td></tr>
+<tr><th>Last Seen</th><td>{{time .LastSeen}}</td></tr>
+{{- with .AssignedTo}}<tr><th>Assigned To</th><td>{{.Name}}</td></tr>{{end}}
+</table>
+{{end}}
+{{define "event"}}
+<p class="meta">Event {{.EventID}}{{with .Platform}} · {{.}}{{end}}{{with .Environment}} · {{.}}{{end}}{{with .Release}} · release {{.Version}}{{end}} · {{time .DateCreated}}</p>
+{{- with .Message}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- range .Exceptions}}
+<h2>{{.Type}}{{with .Handled}} <span class="badge">{{.}}</span>{{end}}</h2>
+{{- with .Value}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- range .Frames}}{{template "frame" .}}{{end}}
+{{- end}}
+{{- if .Threads}}
+<h2>Threads</h2>
+{{- range .Threads}}
+<details class="thread{{if .Crashed}} crashed{{end}}"{{if .Crashed}} open{{end}}>
+<summary>{{.Label}}{{with .State}} <span class="badge">{{.}}</span>{{end}}</summary>
+{{- range .Frames}}{{template "frame" .}}{{end}}
+</details>
+{{- end}}
+{{- end}}
+{{- if .Breadcrumbs}}
+<h2>Breadcrumbs</h2>
+<table class="timeline">
+<tr><th>Time</th><th>Category</th><th>Level</th><th>Message</th></tr>
+{{- range .Breadcrumbs}}
+<tr><td class="time">{{time .Timestamp}}</td><td>{{.Category}}{{with .Type}} <span class="meta">{{.}}</span>{{end}}</td><td>{{.Level}}</td><td>{{.Message}}{{with .Data}}<pre>{{json .}}</pre>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with .Request}}
+<h2>Request</h2>
+<p><code>{{.Method}} {{.URL}}</code></p>
+{{- end}}
+{{- if .Tags}}
+<h2>Tags</h2>
+<table>
+<tr><th>Key</th><th>Value</th></tr>
+{{- range .Tags}}
+<tr><td>{{.Key}}</td><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{end}}
+{{define "frame"}}
+<details class="frame{{if not .InApp}} system{{end}}"{{if .InApp}} open{{end}}>
+<summary><code>{{.Function}}</code> in <code>{{.Location}}</code>{{if not .InApp}} <span class="badge">system</span>{{end}}</summary>
+{{- if .Lines}}
+<table class="source">
+{{- range .Lines}}
+<tr{{if .Current}} class="current"{{end}}><td class="lineno">{{if .No}}{{.No}}{{end}}</td><td>{{.Code}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Vars}}
+<table class="vars">
+{{- range .Vars}}
+<tr><th><code>{{.Name}}</code></th><td><code>{{.Value}}</code></td></tr>
+{{- end}}
+</table>
+{{- end}}
+</details>
+{{- end}}
+{{define "table"}}
+{{- if .Rows}}
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No results found.</p>
+{{- end}}
+{{end}}
+`
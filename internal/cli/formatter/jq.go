@@ -0,0 +1,104 @@
+package formatter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sentire/internal/filter"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// JQError reports an invalid --jq program or a failure while running it
+type JQError struct {
+	Message string
+}
+
+func (e *JQError) Error() string {
+	return e.Message
+}
+
+// CompileJQ parses and compiles a --jq program
+func CompileJQ(source string) (*gojq.Code, error) {
+	query, err := gojq.Parse(source)
+	if err != nil {
+		return nil, &JQError{Message: fmt.Sprintf("invalid --jq: %v", err)}
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, &JQError{Message: fmt.Sprintf("invalid --jq: %v", err)}
+	}
+	return code, nil
+}
+
+// outputJQ runs the --jq program on data, after --fields is applied, and
+// writes each result the way jq does: indented JSON for the json format,
+// one compact JSON value per line for ndjson, and strings unquoted for text
+// (like jq -r).
+func outputJQ(ctx context.Context, cmd *cobra.Command, writer io.Writer, source string, data interface{}) error {
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case "json", "ndjson", "text":
+	default:
+		return &FormatError{Message: fmt.Sprintf("--jq does not support the %s format (use json, ndjson or text)", format)}
+	}
+
+	code, err := CompileJQ(source)
+	if err != nil {
+		return err
+	}
+
+	fieldsSpec, _ := cmd.Flags().GetString("fields")
+	fields, err := parseFields(fieldsSpec)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		data = selectData(data, fields)
+	}
+
+	// An empty listing is an empty array, not null
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		data = []interface{}{}
+	}
+
+	results := code.RunWithContext(ctx, filter.ToJSONValue(data))
+	for {
+		v, ok := results.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := v.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				return nil
+			}
+			return &JQError{Message: fmt.Sprintf("--jq: %v", err)}
+		}
+		if err := writeJQResult(writer, format, v); err != nil {
+			return err
+		}
+	}
+}
+
+func writeJQResult(writer io.Writer, format string, v interface{}) error {
+	if s, ok := v.(string); ok && format == "text" {
+		_, err := fmt.Fprintln(writer, s)
+		return err
+	}
+
+	var b []byte
+	var err error
+	if format == "json" {
+		b, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "%s\n", b)
+	return err
+}
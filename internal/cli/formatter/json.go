@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"sentire/pkg/models"
 )
@@ -9,12 +10,13 @@ import (
 // JSONFormatter outputs data in JSON format (default behavior)
 type JSONFormatter struct {
 	writer io.Writer
-	fields []string
+
+	records int
 }
 
 // NewJSONFormatter creates a new JSON formatter
-func NewJSONFormatter(writer io.Writer, fields []string) *JSONFormatter {
-	return &JSONFormatter{writer: writer, fields: fields}
+func NewJSONFormatter(writer io.Writer) *JSONFormatter {
+	return &JSONFormatter{writer: writer}
 }
 
 // FormatEvent formats a single event as JSON
@@ -54,8 +56,43 @@ func (f *JSONFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
 
 // FormatGeneric formats any data as JSON
 func (f *JSONFormatter) FormatGeneric(data interface{}) error {
-	data = filterFields(data, f.fields)
 	encoder := json.NewEncoder(f.writer)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
+
+// Begin starts a streamed JSON array. The opening bracket is written with
+// the first record, so a listing that fails before it writes nothing.
+func (f *JSONFormatter) Begin() error {
+	f.records = 0
+	return nil
+}
+
+// Record writes one element of a streamed JSON array, indented the same
+// way FormatGeneric indents a whole slice
+func (f *JSONFormatter) Record(item interface{}) error {
+	b, err := json.MarshalIndent(item, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if f.records == 0 {
+		separator = "[\n"
+	}
+	if _, err := io.WriteString(f.writer, separator); err != nil {
+		return err
+	}
+	f.records++
+	_, err = fmt.Fprintf(f.writer, "  %s", b)
+	return err
+}
+
+// End closes a streamed JSON array
+func (f *JSONFormatter) End() error {
+	closing := "\n]\n"
+	if f.records == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(f.writer, closing)
+	return err
+}
@@ -11,6 +11,8 @@ import (
 // MarkdownFormatter outputs data in markdown format
 type MarkdownFormatter struct {
 	writer io.Writer
+
+	records []interface{}
 }
 
 // NewMarkdownFormatter creates a new markdown formatter
@@ -58,10 +60,40 @@ func (f *MarkdownFormatter) FormatEvent(event *models.Event) error {
 		}
 	}
 
+	if threads := models.CrashedFirst(event.Threads()); len(threads) > 0 {
+		fmt.Fprintf(f.writer, "\n## Threads\n\n")
+		f.writeThreadTable(threads)
+		if crashed := threads[0]; crashed.Crashed && len(threadFrames(crashed)) > 0 {
+			fmt.Fprintf(f.writer, "\n### %s\n\n", escapeMarkdown(threadLabel(crashed)))
+			fmt.Fprintf(f.writer, "```\n")
+			frames := threadFrames(crashed)
+			for i := len(frames) - 1; i >= 0; i-- {
+				fmt.Fprintf(f.writer, "at %s\n", frameSummary(frames[i]))
+			}
+			fmt.Fprintf(f.writer, "```\n")
+		}
+	}
+
 	fmt.Fprintf(f.writer, "\n")
 	return nil
 }
 
+// writeThreadTable writes threads as a markdown table
+func (f *MarkdownFormatter) writeThreadTable(threads []models.Thread) {
+	fmt.Fprintf(f.writer, "| ID | Name | State | Crashed | Current | Frames |\n")
+	fmt.Fprintf(f.writer, "|----|----|----|----|----|----|\n")
+
+	for _, thread := range threads {
+		fmt.Fprintf(f.writer, "| %s | %s | %s | %v | %v | %d |\n",
+			formatFieldValue(thread.ID),
+			escapeMarkdown(thread.Name),
+			thread.State,
+			thread.Crashed,
+			thread.Current,
+			len(threadFrames(thread)))
+	}
+}
+
 // FormatEvents formats multiple events as markdown
 func (f *MarkdownFormatter) FormatEvents(events []models.Event) error {
 	if len(events) == 0 {
@@ -269,6 +301,17 @@ func (f *MarkdownFormatter) FormatGeneric(data interface{}) error {
 				projects[i] = v.Index(i).Interface().(models.Project)
 			}
 			return f.FormatProjects(projects)
+		case models.Thread:
+			threads := make([]models.Thread, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				threads[i] = v.Index(i).Interface().(models.Thread)
+			}
+			fmt.Fprintf(f.writer, "# Threads (%d total)\n\n", len(threads))
+			f.writeThreadTable(threads)
+			fmt.Fprintf(f.writer, "\n")
+			return nil
+		case selection:
+			return f.formatSelections(v)
 		default:
 			// Fallback to simple list for unknown types
 			return f.formatUnknownSlice(data)
@@ -276,9 +319,37 @@ func (f *MarkdownFormatter) FormatGeneric(data interface{}) error {
 	}
 
 	// Handle single values
+	if sel, ok := data.(selection); ok {
+		fmt.Fprintf(f.writer, "# Details\n\n")
+		values := sel.strings()
+		for i, name := range sel.names() {
+			fmt.Fprintf(f.writer, "**%s**: %s  \n", name, escapeMarkdown(values[i]))
+		}
+		fmt.Fprintf(f.writer, "\n")
+		return nil
+	}
 	return f.formatSingleValue(data)
 }
 
+// formatSelections formats records reduced by --fields as a markdown table
+func (f *MarkdownFormatter) formatSelections(v reflect.Value) error {
+	names := v.Index(0).Interface().(selection).names()
+	fmt.Fprintf(f.writer, "# Results (%d total)\n\n", v.Len())
+	fmt.Fprintf(f.writer, "| %s |\n", strings.Join(names, " | "))
+	fmt.Fprintf(f.writer, "|%s\n", strings.Repeat("----|", len(names)))
+
+	for i := 0; i < v.Len(); i++ {
+		values := v.Index(i).Interface().(selection).strings()
+		for j := range values {
+			values[j] = escapeMarkdown(values[j])
+		}
+		fmt.Fprintf(f.writer, "| %s |\n", strings.Join(values, " | "))
+	}
+
+	fmt.Fprintf(f.writer, "\n")
+	return nil
+}
+
 // formatUnknownSlice formats a slice of unknown type as markdown
 func (f *MarkdownFormatter) formatUnknownSlice(data interface{}) error {
 	v := reflect.ValueOf(data)
@@ -336,6 +407,26 @@ func (f *MarkdownFormatter) formatSingleValue(data interface{}) error {
 	return nil
 }
 
+// Begin starts a streamed list. Markdown lists open with a total count,
+// so records are buffered and rendered by End.
+func (f *MarkdownFormatter) Begin() error {
+	f.records = nil
+	return nil
+}
+
+// Record buffers one record of a streamed list
+func (f *MarkdownFormatter) Record(item interface{}) error {
+	f.records = append(f.records, item)
+	return nil
+}
+
+// End renders the buffered records
+func (f *MarkdownFormatter) End() error {
+	records := f.records
+	f.records = nil
+	return f.FormatGeneric(records)
+}
+
 // Helper functions
 func escapeMarkdown(s string) string {
 	// Escape common markdown characters that might break table formatting
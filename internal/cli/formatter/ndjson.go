@@ -10,12 +10,11 @@ import (
 // NDJSONFormatter outputs data as newline-delimited JSON (one object per line)
 type NDJSONFormatter struct {
 	writer io.Writer
-	fields []string
 }
 
 // NewNDJSONFormatter creates a new NDJSON formatter
-func NewNDJSONFormatter(writer io.Writer, fields []string) *NDJSONFormatter {
-	return &NDJSONFormatter{writer: writer, fields: fields}
+func NewNDJSONFormatter(writer io.Writer) *NDJSONFormatter {
+	return &NDJSONFormatter{writer: writer}
 }
 
 func (f *NDJSONFormatter) FormatEvent(event *models.Event) error {
@@ -77,7 +76,21 @@ func (f *NDJSONFormatter) FormatGeneric(data interface{}) error {
 	return f.writeLine(data)
 }
 
+// Begin starts a streamed list; nothing is written ahead of the records
+func (f *NDJSONFormatter) Begin() error {
+	return nil
+}
+
+// Record writes one record as a line
+func (f *NDJSONFormatter) Record(item interface{}) error {
+	return f.writeLine(item)
+}
+
+// End finishes a streamed list; nothing is written after the records
+func (f *NDJSONFormatter) End() error {
+	return nil
+}
+
 func (f *NDJSONFormatter) writeLine(data interface{}) error {
-	data = filterFields(data, f.fields)
 	return json.NewEncoder(f.writer).Encode(data)
 }
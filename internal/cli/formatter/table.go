@@ -7,17 +7,24 @@ import (
 	"sentire/pkg/models"
 	"strconv"
 
+	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
 )
 
-// TableFormatter outputs data in table format
+// TableFormatter outputs data in table format, coloring issue levels and
+// statuses when the color mode allows it
 type TableFormatter struct {
 	writer io.Writer
+	colors styles
+
+	stream  *tablewriter.Table
+	records int
 }
 
 // NewTableFormatter creates a new table formatter
-func NewTableFormatter(writer io.Writer) *TableFormatter {
-	return &TableFormatter{writer: writer}
+func NewTableFormatter(writer io.Writer, mode ColorMode) *TableFormatter {
+	return &TableFormatter{writer: writer, colors: newStyles(writer, mode)}
 }
 
 // FormatEvent formats a single event as a table
@@ -50,6 +57,12 @@ func (f *TableFormatter) FormatEvent(event *models.Event) error {
 	if event.Environment != "" {
 		rows = append(rows, []string{"Environment", event.Environment})
 	}
+	if threads := models.CrashedFirst(event.Threads()); len(threads) > 0 {
+		rows = append(rows, []string{"Threads", strconv.Itoa(len(threads))})
+		if threads[0].Crashed {
+			rows = append(rows, []string{"Crashed Thread", threadLabel(threads[0])})
+		}
+	}
 
 	for _, row := range rows {
 		err := table.Append(row)
@@ -70,19 +83,10 @@ func (f *TableFormatter) FormatEvents(events []models.Event) error {
 	}
 
 	table := tablewriter.NewWriter(f.writer)
-	table.Header("ID", "Title", "Type", "Platform", "Project ID", "Date Created", "Environment")
+	table.Header(eventColumns.headers())
 
 	for _, event := range events {
-		row := []string{
-			event.EventID,
-			truncateString(event.Title, 30),
-			event.Type,
-			event.Platform,
-			event.ProjectID,
-			event.DateCreated.Format("2006-01-02 15:04"),
-			event.Environment,
-		}
-		err := table.Append(row)
+		err := table.Append(eventRow(event))
 		if err != nil {
 			return err
 		}
@@ -101,8 +105,8 @@ func (f *TableFormatter) FormatIssue(issue *models.Issue) error {
 		{"ID", issue.ID},
 		{"Short ID", issue.ShortID},
 		{"Title", issue.Title},
-		{"Level", issue.Level},
-		{"Status", issue.Status},
+		{"Level", f.colors.level(issue.Level)},
+		{"Status", f.colors.status(issue.Status)},
 		{"Platform", issue.Platform},
 		{"Project", fmt.Sprintf("%s (%s)", issue.Project.Name, issue.Project.Slug)},
 		{"Count", issue.Count},
@@ -146,20 +150,10 @@ func (f *TableFormatter) FormatIssues(issues []models.Issue) error {
 	}
 
 	table := tablewriter.NewWriter(f.writer)
-	table.Header("ID", "Title", "Level", "Status", "Count", "User Count", "Last Seen", "Project")
+	table.Header(issueColumns.headers())
 
 	for _, issue := range issues {
-		row := []string{
-			issue.ShortID,
-			truncateString(issue.Title, 30),
-			issue.Level,
-			issue.Status,
-			issue.Count,
-			strconv.Itoa(issue.UserCount),
-			issue.LastSeen.Format("01-02 15:04"),
-			issue.Project.Slug,
-		}
-		err := table.Append(row)
+		err := table.Append(issueRow(issue, f.colors))
 		if err != nil {
 			return err
 		}
@@ -205,18 +199,10 @@ func (f *TableFormatter) FormatProjects(projects []models.Project) error {
 	}
 
 	table := tablewriter.NewWriter(f.writer)
-	table.Header("Slug", "Name", "Platform", "Organization", "Status", "Date Created")
+	table.Header(projectColumns.headers())
 
 	for _, project := range projects {
-		row := []string{
-			project.Slug,
-			truncateString(project.Name, 25),
-			project.Platform,
-			project.Organization.Slug,
-			project.Status,
-			project.DateCreated.Format("2006-01-02"),
-		}
-		err := table.Append(row)
+		err := table.Append(projectRow(project))
 		if err != nil {
 			return err
 		}
@@ -283,6 +269,10 @@ func (f *TableFormatter) FormatGeneric(data interface{}) error {
 				projects[i] = v.Index(i).Interface().(models.Project)
 			}
 			return f.FormatProjects(projects)
+		case models.Thread:
+			return f.formatThreads(v)
+		case selection:
+			return f.formatSelections(v)
 		default:
 			// Fallback to simple key-value table for unknown types
 			return f.formatUnknownSlice(data)
@@ -290,9 +280,61 @@ func (f *TableFormatter) FormatGeneric(data interface{}) error {
 	}
 
 	// Handle single values
+	if sel, ok := data.(selection); ok {
+		return f.formatSelection(sel)
+	}
 	return f.formatSingleValue(data)
 }
 
+// formatThreads formats threads, one row per thread
+func (f *TableFormatter) formatThreads(v reflect.Value) error {
+	table := tablewriter.NewWriter(f.writer)
+	table.Header(threadColumns.headers())
+
+	for i := 0; i < v.Len(); i++ {
+		err := table.Append(threadRow(v.Index(i).Interface().(models.Thread), f.colors))
+		if err != nil {
+			return err
+		}
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatSelections formats records reduced by --fields, one column per field
+func (f *TableFormatter) formatSelections(v reflect.Value) error {
+	table := tablewriter.NewWriter(f.writer)
+	table.Header(v.Index(0).Interface().(selection).names())
+
+	for i := 0; i < v.Len(); i++ {
+		err := table.Append(v.Index(i).Interface().(selection).strings())
+		if err != nil {
+			return err
+		}
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatSelection formats a record reduced by --fields as a key-value table
+func (f *TableFormatter) formatSelection(sel selection) error {
+	table := tablewriter.NewWriter(f.writer)
+	table.Header("Field", "Value")
+
+	values := sel.strings()
+	for i, name := range sel.names() {
+		err := table.Append([]string{name, values[i]})
+		if err != nil {
+			return err
+		}
+	}
+
+	table.Render()
+	return nil
+}
+
 // formatUnknownSlice formats a slice of unknown type
 func (f *TableFormatter) formatUnknownSlice(data interface{}) error {
 	v := reflect.ValueOf(data)
@@ -356,3 +398,172 @@ func (f *TableFormatter) formatSingleValue(data interface{}) error {
 	table.Render()
 	return nil
 }
+
+// tableColumn is a column of a list table. The width includes cell padding
+// and is only used when streaming, since rows cannot be measured up front.
+type tableColumn struct {
+	header string
+	width  int
+}
+
+type tableColumns []tableColumn
+
+func (c tableColumns) headers() []string {
+	headers := make([]string, len(c))
+	for i, col := range c {
+		headers[i] = col.header
+	}
+	return headers
+}
+
+func (c tableColumns) widths() tw.Mapper[int, int] {
+	widths := tw.NewMapper[int, int]()
+	for i, col := range c {
+		widths.Set(i, col.width)
+	}
+	return widths
+}
+
+var eventColumns = tableColumns{
+	{"ID", 34}, {"Title", 32}, {"Type", 13}, {"Platform", 14},
+	{"Project ID", 12}, {"Date Created", 18}, {"Environment", 16},
+}
+
+var issueColumns = tableColumns{
+	{"ID", 22}, {"Title", 32}, {"Level", 9}, {"Status", 12},
+	{"Count", 9}, {"User Count", 12}, {"Last Seen", 13}, {"Project", 22},
+}
+
+var projectColumns = tableColumns{
+	{"Slug", 24}, {"Name", 27}, {"Platform", 16},
+	{"Organization", 20}, {"Status", 10}, {"Date Created", 14},
+}
+
+var threadColumns = tableColumns{
+	{"ID", 10}, {"Name", 22}, {"State", 14}, {"Crashed", 9},
+	{"Current", 9}, {"Frames", 8}, {"Top Frame", 42},
+}
+
+var valueColumns = tableColumns{{"Index", 8}, {"Value", 72}}
+
+// selectionColumns returns one column per --fields entry
+func selectionColumns(sel selection) tableColumns {
+	columns := make(tableColumns, len(sel.fields))
+	for i, name := range sel.names() {
+		columns[i] = tableColumn{name, max(len(name)+2, 24)}
+	}
+	return columns
+}
+
+func eventRow(event models.Event) []string {
+	return []string{
+		event.EventID,
+		truncateString(event.Title, 30),
+		event.Type,
+		event.Platform,
+		event.ProjectID,
+		event.DateCreated.Format("2006-01-02 15:04"),
+		event.Environment,
+	}
+}
+
+func issueRow(issue models.Issue, colors styles) []string {
+	return []string{
+		issue.ShortID,
+		truncateString(issue.Title, 30),
+		colors.level(issue.Level),
+		colors.status(issue.Status),
+		issue.Count,
+		strconv.Itoa(issue.UserCount),
+		issue.LastSeen.Format("01-02 15:04"),
+		issue.Project.Slug,
+	}
+}
+
+func projectRow(project models.Project) []string {
+	return []string{
+		project.Slug,
+		truncateString(project.Name, 25),
+		project.Platform,
+		project.Organization.Slug,
+		project.Status,
+		project.DateCreated.Format("2006-01-02"),
+	}
+}
+
+func threadRow(thread models.Thread, colors styles) []string {
+	crashed := "no"
+	if thread.Crashed {
+		crashed = colors.paint("yes", color.FgRed, color.Bold)
+	}
+	current := "no"
+	if thread.Current {
+		current = "yes"
+	}
+	top := ""
+	frames := threadFrames(thread)
+	if len(frames) > 0 {
+		top = truncateString(frameSummary(frames[len(frames)-1]), 40)
+	}
+	return []string{
+		formatFieldValue(thread.ID),
+		truncateString(thread.Name, 20),
+		thread.State,
+		crashed,
+		current,
+		strconv.Itoa(len(frames)),
+		top,
+	}
+}
+
+// Begin starts a streamed table
+func (f *TableFormatter) Begin() error {
+	f.stream = nil
+	f.records = 0
+	return nil
+}
+
+// Record appends a row to the streamed table. The columns are chosen from
+// the type of the first record.
+func (f *TableFormatter) Record(item interface{}) error {
+	var columns tableColumns
+	var row []string
+	switch v := item.(type) {
+	case models.Event:
+		columns, row = eventColumns, eventRow(v)
+	case models.Issue:
+		columns, row = issueColumns, issueRow(v, f.colors)
+	case models.Project:
+		columns, row = projectColumns, projectRow(v)
+	case models.Thread:
+		columns, row = threadColumns, threadRow(v, f.colors)
+	case selection:
+		columns, row = selectionColumns(v), v.strings()
+	default:
+		columns, row = valueColumns, []string{strconv.Itoa(f.records), fmt.Sprintf("%v", v)}
+	}
+
+	if f.stream == nil {
+		f.stream = tablewriter.NewTable(f.writer,
+			tablewriter.WithStreaming(tw.StreamConfig{Enable: true}),
+			tablewriter.WithWidths(tw.CellWidth{PerColumn: columns.widths()}),
+		)
+		if err := f.stream.Start(); err != nil {
+			return err
+		}
+		f.stream.Header(columns.headers())
+	}
+	f.records++
+	return f.stream.Append(row)
+}
+
+// End closes the streamed table
+func (f *TableFormatter) End() error {
+	if f.stream == nil {
+		fmt.Fprintf(f.writer, "No data found\n")
+		return nil
+	}
+	err := f.stream.Close()
+	f.stream = nil
+	return err
+}
@@ -0,0 +1,248 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sentire/pkg/models"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TemplateError reports a missing or invalid --template, or a failure while
+// executing it
+type TemplateError struct {
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	return e.Message
+}
+
+// TemplateFormatter outputs data through a user-defined Go template. The
+// template is executed once per record of a list and once for a single
+// object. Output that does not end with a newline gets one, and executions
+// that produce nothing are skipped, so templates can leave records out.
+type TemplateFormatter struct {
+	writer   io.Writer
+	template *template.Template
+}
+
+// NewTemplateFormatter creates a template formatter from the template
+// source; mode decides whether the color helper writes colors
+func NewTemplateFormatter(writer io.Writer, source string, mode ColorMode) (*TemplateFormatter, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs(newStyles(writer, mode))).Parse(source)
+	if err != nil {
+		return nil, &TemplateError{Message: fmt.Sprintf("invalid template: %v", err)}
+	}
+	return &TemplateFormatter{writer: writer, template: tmpl}, nil
+}
+
+// templateSource returns the template given by --template or
+// --template-file
+func templateSource(cmd *cobra.Command) (string, error) {
+	source, _ := cmd.Flags().GetString("template")
+	file, _ := cmd.Flags().GetString("template-file")
+
+	switch {
+	case source != "" && file != "":
+		return "", &TemplateError{Message: "--template and --template-file cannot be used together"}
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", &TemplateError{Message: fmt.Sprintf("failed to read --template-file: %v", err)}
+		}
+		return string(data), nil
+	case source != "":
+		return source, nil
+	}
+	return "", &TemplateError{Message: "--format template requires --template or --template-file"}
+}
+
+// templateFuncs returns the helper functions available to templates
+func templateFuncs(colors styles) template.FuncMap {
+	return template.FuncMap{
+		// {{.Title | truncate 40}}
+		"truncate": truncateRunes,
+		// {{.LastSeen | timeago}}
+		"timeago": timeAgo,
+		// {{.Tags | json}}
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		// {{.Fingerprint | join ", "}}
+		"join": func(sep string, list interface{}) (string, error) {
+			v := reflect.ValueOf(list)
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return "", fmt.Errorf("join: expected a list, got %T", list)
+			}
+			parts := make([]string, v.Len())
+			for i := range parts {
+				parts[i] = fmt.Sprint(v.Index(i).Interface())
+			}
+			return strings.Join(parts, sep), nil
+		},
+		// {{range crashedFirst .Threads}}
+		"crashedFirst": models.CrashedFirst,
+		// {{.Level | color "red"}}; colors follow --color
+		"color": func(name string, s string) (string, error) {
+			attrs, ok := namedColors[name]
+			if !ok {
+				return "", fmt.Errorf("color: unknown color %q", name)
+			}
+			return colors.paint(s, attrs...), nil
+		},
+	}
+}
+
+// truncateRunes shortens s to at most n characters, ending with "..." when
+// there is room for it. Multi-byte characters are never split.
+func truncateRunes(n int, s string) string {
+	runes := []rune(s)
+	switch {
+	case n <= 0:
+		return ""
+	case len(runes) <= n:
+		return s
+	case n <= 3:
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// timeAgo formats a time relative to now, such as "3h ago"
+func timeAgo(v interface{}) (string, error) {
+	var t time.Time
+	switch x := v.(type) {
+	case time.Time:
+		t = x
+	case *time.Time:
+		if x == nil {
+			return "", nil
+		}
+		t = *x
+	case string:
+		parsed, err := time.Parse(time.RFC3339, x)
+		if err != nil {
+			return "", fmt.Errorf("timeago: %v", err)
+		}
+		t = parsed
+	default:
+		return "", fmt.Errorf("timeago: expected a time, got %T", v)
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now", nil
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes())), nil
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours())), nil
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24)), nil
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30)), nil
+	}
+	return fmt.Sprintf("%dy ago", int(d.Hours()/24/365)), nil
+}
+
+// FormatEvent formats a single event through the template
+func (f *TemplateFormatter) FormatEvent(event *models.Event) error {
+	return f.execute(event)
+}
+
+// FormatEvents formats each event through the template
+func (f *TemplateFormatter) FormatEvents(events []models.Event) error {
+	return f.FormatGeneric(events)
+}
+
+// FormatIssue formats a single issue through the template
+func (f *TemplateFormatter) FormatIssue(issue *models.Issue) error {
+	return f.execute(issue)
+}
+
+// FormatIssues formats each issue through the template
+func (f *TemplateFormatter) FormatIssues(issues []models.Issue) error {
+	return f.FormatGeneric(issues)
+}
+
+// FormatProject formats a single project through the template
+func (f *TemplateFormatter) FormatProject(project *models.Project) error {
+	return f.execute(project)
+}
+
+// FormatProjects formats each project through the template
+func (f *TemplateFormatter) FormatProjects(projects []models.Project) error {
+	return f.FormatGeneric(projects)
+}
+
+// FormatOrgStats formats organization stats through the template
+func (f *TemplateFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
+	return f.execute(stats)
+}
+
+// FormatGeneric executes the template for each element of a list, or once
+// for anything else
+func (f *TemplateFormatter) FormatGeneric(data interface{}) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			if err := f.execute(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return f.execute(data)
+}
+
+// Begin starts a streamed list; nothing is written ahead of the records
+func (f *TemplateFormatter) Begin() error {
+	return nil
+}
+
+// Record executes the template for one record
+func (f *TemplateFormatter) Record(item interface{}) error {
+	return f.execute(item)
+}
+
+// End finishes a streamed list; nothing is written after the records
+func (f *TemplateFormatter) End() error {
+	return nil
+}
+
+// execute runs the template on one value. Records reduced by --fields are
+// passed as a map from field name to value.
+func (f *TemplateFormatter) execute(data interface{}) error {
+	if sel, ok := data.(selection); ok {
+		m := make(map[string]interface{}, len(sel.fields))
+		for i, name := range sel.names() {
+			m[name] = sel.values[i]
+		}
+		data = m
+	}
+
+	var buf bytes.Buffer
+	if err := f.template.Execute(&buf, data); err != nil {
+		return &TemplateError{Message: fmt.Sprintf("template failed: %v", err)}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	if buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	_, err := f.writer.Write(buf.Bytes())
+	return err
+}
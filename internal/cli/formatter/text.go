@@ -6,16 +6,22 @@ import (
 	"reflect"
 	"sentire/pkg/models"
 	"strings"
+
+	"github.com/fatih/color"
 )
 
-// TextFormatter outputs data in plain text format
+// TextFormatter outputs data in plain text format, colored by severity
+// when the color mode allows it
 type TextFormatter struct {
 	writer io.Writer
+	colors styles
+
+	records int
 }
 
 // NewTextFormatter creates a new text formatter
-func NewTextFormatter(writer io.Writer) *TextFormatter {
-	return &TextFormatter{writer: writer}
+func NewTextFormatter(writer io.Writer, mode ColorMode) *TextFormatter {
+	return &TextFormatter{writer: writer, colors: newStyles(writer, mode)}
 }
 
 // FormatEvent formats a single event as text
@@ -55,10 +61,95 @@ func (f *TextFormatter) FormatEvent(event *models.Event) error {
 		}
 	}
 
+	f.writeExceptions(event)
+	f.writeThreads(event)
+	f.writeBreadcrumbs(event)
+
 	fmt.Fprintf(f.writer, "\n")
 	return nil
 }
 
+// writeExceptions writes the exceptions of an event with their stack
+// frames, most recent first; in-app frames stand out from system frames
+func (f *TextFormatter) writeExceptions(event *models.Event) {
+	exceptions := event.Exceptions()
+	for i := len(exceptions) - 1; i >= 0; i-- {
+		exception := exceptions[i]
+		fmt.Fprintf(f.writer, "\n%s %s\n", f.colors.heading(exception.Type+":"), exception.Value)
+		if exception.Stacktrace == nil {
+			continue
+		}
+		f.writeStack(exception.Stacktrace.Frames, "  ")
+	}
+}
+
+// writeStack writes stack frames most recent first
+func (f *TextFormatter) writeStack(frames []models.StackFrame, indent string) {
+	for i := len(frames) - 1; i >= 0; i-- {
+		fmt.Fprintf(f.writer, "%s%s\n", indent, f.colors.frame("at "+frameSummary(frames[i]), isInApp(frames[i])))
+	}
+}
+
+// writeThreads writes the threads of an event, the crashed one first with
+// its stack frames
+func (f *TextFormatter) writeThreads(event *models.Event) {
+	threads := models.CrashedFirst(event.Threads())
+	if len(threads) == 0 {
+		return
+	}
+	fmt.Fprintf(f.writer, "\nThreads:\n")
+	for _, thread := range threads {
+		label := threadLabel(thread)
+		if thread.Crashed {
+			label = f.colors.paint(label, color.FgRed, color.Bold)
+		}
+		if thread.State != "" {
+			label += " [" + thread.State + "]"
+		}
+		fmt.Fprintf(f.writer, "  %s\n", label)
+		if thread.Crashed {
+			f.writeStack(threadFrames(thread), "    ")
+		}
+	}
+}
+
+// writeThreadItem writes the numbered summary of a thread in a list
+func (f *TextFormatter) writeThreadItem(n int, thread models.Thread) {
+	label := threadLabel(thread)
+	if thread.Crashed {
+		label = f.colors.paint(label, color.FgRed, color.Bold)
+	}
+	fmt.Fprintf(f.writer, "%d. %s\n", n, label)
+	frames := threadFrames(thread)
+	state := thread.State
+	if state == "" {
+		state = "-"
+	}
+	fmt.Fprintf(f.writer, "   State: %s | Frames: %d\n", state, len(frames))
+	if len(frames) > 0 {
+		top := frames[len(frames)-1]
+		fmt.Fprintf(f.writer, "   Top Frame: %s\n", f.colors.frame(frameSummary(top), isInApp(top)))
+	}
+	fmt.Fprintf(f.writer, "\n")
+}
+
+// writeBreadcrumbs writes the breadcrumb trail of an event
+func (f *TextFormatter) writeBreadcrumbs(event *models.Event) {
+	breadcrumbs := event.BreadcrumbValues()
+	if len(breadcrumbs) == 0 {
+		return
+	}
+	fmt.Fprintf(f.writer, "\nBreadcrumbs:\n")
+	for _, crumb := range breadcrumbs {
+		level := crumb.Level
+		if level == "" {
+			level = "info"
+		}
+		fmt.Fprintf(f.writer, "  %s [%s] %s: %s\n",
+			crumb.Timestamp.Format("15:04:05"), f.colors.level(level), crumb.Category, crumb.Message)
+	}
+}
+
 // FormatEvents formats multiple events as text
 func (f *TextFormatter) FormatEvents(events []models.Event) error {
 	if len(events) == 0 {
@@ -69,13 +160,7 @@ func (f *TextFormatter) FormatEvents(events []models.Event) error {
 	fmt.Fprintf(f.writer, "Events (%d total):\n\n", len(events))
 
 	for i, event := range events {
-		fmt.Fprintf(f.writer, "%d. Event #%s\n", i+1, event.EventID)
-		fmt.Fprintf(f.writer, "   Title: %s\n", event.Title)
-		fmt.Fprintf(f.writer, "   Type: %s | Platform: %s | Project ID: %s\n",
-			event.Type, event.Platform, event.ProjectID)
-		fmt.Fprintf(f.writer, "   Date: %s | Environment: %s\n",
-			event.DateCreated.Format("2006-01-02 15:04"), event.Environment)
-		fmt.Fprintf(f.writer, "\n")
+		f.writeEventItem(i+1, event)
 	}
 
 	return nil
@@ -85,8 +170,8 @@ func (f *TextFormatter) FormatEvents(events []models.Event) error {
 func (f *TextFormatter) FormatIssue(issue *models.Issue) error {
 	fmt.Fprintf(f.writer, "Issue #%s (%s)\n", issue.ID, issue.ShortID)
 	fmt.Fprintf(f.writer, "Title: %s\n", issue.Title)
-	fmt.Fprintf(f.writer, "Level: %s\n", issue.Level)
-	fmt.Fprintf(f.writer, "Status: %s", issue.Status)
+	fmt.Fprintf(f.writer, "Level: %s\n", f.colors.level(issue.Level))
+	fmt.Fprintf(f.writer, "Status: %s", f.colors.status(issue.Status))
 
 	if issue.Substatus != "" {
 		fmt.Fprintf(f.writer, " (%s)", issue.Substatus)
@@ -133,15 +218,7 @@ func (f *TextFormatter) FormatIssues(issues []models.Issue) error {
 	fmt.Fprintf(f.writer, "Issues (%d total):\n\n", len(issues))
 
 	for i, issue := range issues {
-		fmt.Fprintf(f.writer, "%d. Issue #%s\n", i+1, issue.ShortID)
-		fmt.Fprintf(f.writer, "   Title: %s\n", issue.Title)
-		fmt.Fprintf(f.writer, "   Level: %s | Status: %s | Count: %s\n",
-			issue.Level, issue.Status, issue.Count)
-		fmt.Fprintf(f.writer, "   Project: %s | Users: %d\n",
-			issue.Project.Slug, issue.UserCount)
-		fmt.Fprintf(f.writer, "   Last Seen: %s\n",
-			issue.LastSeen.Format("2006-01-02 15:04"))
-		fmt.Fprintf(f.writer, "\n")
+		f.writeIssueItem(i+1, issue)
 	}
 
 	return nil
@@ -172,12 +249,7 @@ func (f *TextFormatter) FormatProjects(projects []models.Project) error {
 	fmt.Fprintf(f.writer, "Projects (%d total):\n\n", len(projects))
 
 	for i, project := range projects {
-		fmt.Fprintf(f.writer, "%d. %s (%s)\n", i+1, project.Name, project.Slug)
-		fmt.Fprintf(f.writer, "   Platform: %s | Organization: %s\n",
-			project.Platform, project.Organization.Slug)
-		fmt.Fprintf(f.writer, "   Status: %s | Created: %s\n",
-			project.Status, project.DateCreated.Format("2006-01-02"))
-		fmt.Fprintf(f.writer, "\n")
+		f.writeProjectItem(i+1, project)
 	}
 
 	return nil
@@ -242,6 +314,18 @@ func (f *TextFormatter) FormatGeneric(data interface{}) error {
 				projects[i] = v.Index(i).Interface().(models.Project)
 			}
 			return f.FormatProjects(projects)
+		case models.Thread:
+			fmt.Fprintf(f.writer, "Threads (%d total):\n\n", v.Len())
+			for i := 0; i < v.Len(); i++ {
+				f.writeThreadItem(i+1, v.Index(i).Interface().(models.Thread))
+			}
+			return nil
+		case selection:
+			fmt.Fprintf(f.writer, "Results (%d total):\n\n", v.Len())
+			for i := 0; i < v.Len(); i++ {
+				f.writeSelectionItem(i+1, v.Index(i).Interface().(selection))
+			}
+			return nil
 		default:
 			// Fallback to simple list for unknown types
 			return f.formatUnknownSlice(data)
@@ -249,6 +333,14 @@ func (f *TextFormatter) FormatGeneric(data interface{}) error {
 	}
 
 	// Handle single values
+	if sel, ok := data.(selection); ok {
+		values := sel.strings()
+		for i, name := range sel.names() {
+			fmt.Fprintf(f.writer, "%s: %s\n", name, values[i])
+		}
+		fmt.Fprintf(f.writer, "\n")
+		return nil
+	}
 	return f.formatSingleValue(data)
 }
 
@@ -309,3 +401,88 @@ func (f *TextFormatter) formatSingleValue(data interface{}) error {
 	fmt.Fprintf(f.writer, "\n")
 	return nil
 }
+
+// writeEventItem writes the numbered summary of an event in a list
+func (f *TextFormatter) writeEventItem(n int, event models.Event) {
+	fmt.Fprintf(f.writer, "%d. Event #%s\n", n, event.EventID)
+	fmt.Fprintf(f.writer, "   Title: %s\n", event.Title)
+	fmt.Fprintf(f.writer, "   Type: %s | Platform: %s | Project ID: %s\n",
+		event.Type, event.Platform, event.ProjectID)
+	fmt.Fprintf(f.writer, "   Date: %s | Environment: %s\n",
+		event.DateCreated.Format("2006-01-02 15:04"), event.Environment)
+	fmt.Fprintf(f.writer, "\n")
+}
+
+// writeIssueItem writes the numbered summary of an issue in a list
+func (f *TextFormatter) writeIssueItem(n int, issue models.Issue) {
+	fmt.Fprintf(f.writer, "%d. Issue #%s\n", n, issue.ShortID)
+	fmt.Fprintf(f.writer, "   Title: %s\n", issue.Title)
+	fmt.Fprintf(f.writer, "   Level: %s | Status: %s | Count: %s\n",
+		f.colors.level(issue.Level), f.colors.status(issue.Status), issue.Count)
+	fmt.Fprintf(f.writer, "   Project: %s | Users: %d\n",
+		issue.Project.Slug, issue.UserCount)
+	fmt.Fprintf(f.writer, "   Last Seen: %s\n",
+		issue.LastSeen.Format("2006-01-02 15:04"))
+	fmt.Fprintf(f.writer, "\n")
+}
+
+// writeProjectItem writes the numbered summary of a project in a list
+func (f *TextFormatter) writeProjectItem(n int, project models.Project) {
+	fmt.Fprintf(f.writer, "%d. %s (%s)\n", n, project.Name, project.Slug)
+	fmt.Fprintf(f.writer, "   Platform: %s | Organization: %s\n",
+		project.Platform, project.Organization.Slug)
+	fmt.Fprintf(f.writer, "   Status: %s | Created: %s\n",
+		project.Status, project.DateCreated.Format("2006-01-02"))
+	fmt.Fprintf(f.writer, "\n")
+}
+
+// writeSelectionItem writes the numbered fields of a record reduced by
+// --fields
+func (f *TextFormatter) writeSelectionItem(n int, sel selection) {
+	values := sel.strings()
+	for i, name := range sel.names() {
+		if i == 0 {
+			fmt.Fprintf(f.writer, "%d. %s: %s\n", n, name, values[i])
+		} else {
+			fmt.Fprintf(f.writer, "   %s: %s\n", name, values[i])
+		}
+	}
+	fmt.Fprintf(f.writer, "\n")
+}
+
+// Begin starts a streamed list
+func (f *TextFormatter) Begin() error {
+	f.records = 0
+	return nil
+}
+
+// Record writes the next numbered item of a streamed list. The total is
+// only known at the end, so it is written by End rather than as a header.
+func (f *TextFormatter) Record(item interface{}) error {
+	f.records++
+	switch v := item.(type) {
+	case models.Event:
+		f.writeEventItem(f.records, v)
+	case models.Issue:
+		f.writeIssueItem(f.records, v)
+	case models.Project:
+		f.writeProjectItem(f.records, v)
+	case models.Thread:
+		f.writeThreadItem(f.records, v)
+	case selection:
+		f.writeSelectionItem(f.records, v)
+	default:
+		fmt.Fprintf(f.writer, "%d. %v\n", f.records, v)
+	}
+	return nil
+}
+
+// End writes the total of a streamed list
+func (f *TextFormatter) End() error {
+	if f.records == 0 {
+		fmt.Fprintf(f.writer, "No data found\n")
+		return nil
+	}
+	fmt.Fprintf(f.writer, "Total: %d\n", f.records)
+	return nil
+}
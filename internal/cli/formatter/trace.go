@@ -0,0 +1,348 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"sentire/internal/filter"
+	"sentire/pkg/models"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// TraceFormatter outputs the stack traces of events the way Python prints
+// a traceback: chained exceptions cause first, frames oldest first with the
+// source lines around each in-app frame, and runs of system frames
+// collapsed to a single line. Anything other than an event, or an issue
+// with its recommended event, is formatted as text.
+type TraceFormatter struct {
+	*TextFormatter
+
+	vars   bool
+	raw    bool
+	traces int
+}
+
+// NewTraceFormatter creates a new trace formatter. With vars, the local
+// variables of each shown frame are written too; with raw, the raw stack
+// traces are used, which for minified JavaScript are the frames before
+// source maps were applied.
+func NewTraceFormatter(writer io.Writer, mode ColorMode, vars, raw bool) *TraceFormatter {
+	return &TraceFormatter{TextFormatter: NewTextFormatter(writer, mode), vars: vars, raw: raw}
+}
+
+// FormatEvent writes the stack trace of an event
+func (f *TraceFormatter) FormatEvent(event *models.Event) error {
+	f.writeTrace(event)
+	return nil
+}
+
+// FormatEvents writes the stack trace of each event
+func (f *TraceFormatter) FormatEvents(events []models.Event) error {
+	if len(events) == 0 {
+		return f.TextFormatter.FormatEvents(events)
+	}
+	for i := range events {
+		if i > 0 {
+			fmt.Fprintf(f.writer, "\n")
+		}
+		f.writeTrace(&events[i])
+	}
+	return nil
+}
+
+// FormatIssue writes the stack trace of the issue's recommended event, or
+// the issue as text when it has none
+func (f *TraceFormatter) FormatIssue(issue *models.Issue) error {
+	if issue.RecommendedEvent == nil {
+		return f.TextFormatter.FormatIssue(issue)
+	}
+	f.writeTrace(issue.RecommendedEvent)
+	return nil
+}
+
+// FormatIssues writes the stack traces of the issues' recommended events,
+// fetched with --with-event, or the issues as text without them
+func (f *TraceFormatter) FormatIssues(issues []models.Issue) error {
+	if len(issues) == 0 || issues[0].RecommendedEvent == nil {
+		return f.TextFormatter.FormatIssues(issues)
+	}
+	for i := range issues {
+		if i > 0 {
+			fmt.Fprintf(f.writer, "\n")
+		}
+		f.FormatIssue(&issues[i])
+	}
+	return nil
+}
+
+// FormatGeneric writes events and issues as traces, threads as their
+// stacks and anything else as text
+func (f *TraceFormatter) FormatGeneric(data interface{}) error {
+	switch v := data.(type) {
+	case []models.Thread:
+		f.writeThreads(v)
+		return nil
+	case *models.Event:
+		return f.FormatEvent(v)
+	case *models.Issue:
+		return f.FormatIssue(v)
+	case []interface{}:
+		if len(v) > 0 {
+			if events, ok := sliceOf[models.Event](v); ok {
+				return f.FormatEvents(events)
+			}
+			if issues, ok := sliceOf[models.Issue](v); ok {
+				return f.FormatIssues(issues)
+			}
+		}
+	}
+	return f.TextFormatter.FormatGeneric(data)
+}
+
+// writeThreads writes the stack of each thread
+func (f *TraceFormatter) writeThreads(threads []models.Thread) {
+	if len(threads) == 0 {
+		fmt.Fprintf(f.writer, "No data found\n")
+		return
+	}
+	for i, thread := range threads {
+		if i > 0 {
+			fmt.Fprintf(f.writer, "\n")
+		}
+		f.writeThread(thread)
+	}
+}
+
+// Begin starts a streamed list
+func (f *TraceFormatter) Begin() error {
+	f.traces = 0
+	return f.TextFormatter.Begin()
+}
+
+// Record writes the trace of an event, of an issue's recommended event or
+// of a thread, and anything else as a text list item
+func (f *TraceFormatter) Record(item interface{}) error {
+	var event *models.Event
+	var thread *models.Thread
+	switch v := item.(type) {
+	case models.Event:
+		event = &v
+	case models.Issue:
+		event = v.RecommendedEvent
+	case models.Thread:
+		thread = &v
+	}
+	if event == nil && thread == nil {
+		return f.TextFormatter.Record(item)
+	}
+
+	if f.traces > 0 {
+		fmt.Fprintf(f.writer, "\n")
+	}
+	f.traces++
+	if thread != nil {
+		f.writeThread(*thread)
+	} else {
+		f.writeTrace(event)
+	}
+	return nil
+}
+
+// End finishes a streamed list; the text total is only written for text
+// items
+func (f *TraceFormatter) End() error {
+	if f.traces > 0 && f.records == 0 {
+		return nil
+	}
+	return f.TextFormatter.End()
+}
+
+// writeTrace writes the heading of an event followed by its exceptions
+// and the stacks of its threads, crashed thread first
+func (f *TraceFormatter) writeTrace(event *models.Event) {
+	title := event.Title
+	if title == "" {
+		title = event.Message
+	}
+	fmt.Fprintf(f.writer, "%s\n", f.colors.heading(fmt.Sprintf("Event %s: %s", event.EventID, title)))
+
+	exceptions := event.Exceptions()
+	threads := models.CrashedFirst(event.Threads())
+	if len(exceptions) == 0 && len(threads) == 0 {
+		fmt.Fprintf(f.writer, "\nNo stack trace\n")
+		return
+	}
+
+	// Sentry lists chained exceptions cause first, as Python prints them
+	for i, exception := range exceptions {
+		if i > 0 {
+			fmt.Fprintf(f.writer, "\nThe above exception was the direct cause of the following exception:\n")
+		}
+		fmt.Fprintf(f.writer, "\n")
+		f.writeException(exception)
+	}
+
+	for _, thread := range threads {
+		fmt.Fprintf(f.writer, "\n")
+		f.writeThread(thread)
+	}
+}
+
+// writeThread writes a thread's label followed by its stack
+func (f *TraceFormatter) writeThread(thread models.Thread) {
+	label := threadLabel(thread)
+	if thread.State != "" {
+		label += " [" + thread.State + "]"
+	}
+	if thread.Crashed {
+		label = f.colors.paint(label, color.FgRed, color.Bold)
+	} else {
+		label = f.colors.heading(label)
+	}
+	fmt.Fprintf(f.writer, "%s\n", label)
+
+	stacktrace := thread.Stacktrace
+	if f.raw && thread.RawStacktrace != nil {
+		stacktrace = thread.RawStacktrace
+	}
+	if stacktrace == nil || len(stacktrace.Frames) == 0 {
+		fmt.Fprintf(f.writer, "  No stack trace\n")
+		return
+	}
+	fmt.Fprintf(f.writer, "Traceback (most recent call last):\n")
+	f.writeFrames(stacktrace.Frames)
+}
+
+func (f *TraceFormatter) writeException(exception models.ExceptionValue) {
+	stacktrace := exception.Stacktrace
+	if f.raw && exception.RawStacktrace != nil {
+		stacktrace = exception.RawStacktrace
+	}
+
+	if stacktrace != nil && len(stacktrace.Frames) > 0 {
+		fmt.Fprintf(f.writer, "Traceback (most recent call last):\n")
+		f.writeFrames(stacktrace.Frames)
+	}
+
+	// Exception types are qualified by their module, except built-in ones
+	name := exception.Type
+	if exception.Module != "" && exception.Module != "builtins" && !strings.Contains(name, ".") {
+		name = exception.Module + "." + name
+	}
+	if exception.Value != "" {
+		name += ": " + exception.Value
+	}
+	if exception.Mechanism != nil && exception.Mechanism.Handled != nil && !*exception.Mechanism.Handled {
+		name += " (unhandled)"
+	}
+	fmt.Fprintf(f.writer, "%s\n", f.colors.paint(name, color.FgRed, color.Bold))
+}
+
+// writeFrames writes the frames oldest first. When some frames are in-app,
+// each run of system frames is collapsed to one line and only in-app frames
+// get their source lines.
+func (f *TraceFormatter) writeFrames(frames []models.StackFrame) {
+	hasInApp := false
+	for _, frame := range frames {
+		if isInApp(frame) {
+			hasInApp = true
+			break
+		}
+	}
+
+	hidden := 0
+	flush := func() {
+		if hidden == 0 {
+			return
+		}
+		label := "frames"
+		if hidden == 1 {
+			label = "frame"
+		}
+		fmt.Fprintf(f.writer, "  %s\n", f.colors.frame(fmt.Sprintf("... %d system %s hidden ...", hidden, label), false))
+		hidden = 0
+	}
+
+	for _, frame := range frames {
+		if hasInApp && !isInApp(frame) {
+			hidden++
+			continue
+		}
+		flush()
+		f.writeFrame(frame)
+	}
+	flush()
+}
+
+func (f *TraceFormatter) writeFrame(frame models.StackFrame) {
+	inApp := isInApp(frame)
+
+	file := frame.Filename
+	if file == "" {
+		file = frame.AbsPath
+	}
+	if file == "" {
+		file = frame.Module
+	}
+	location := fmt.Sprintf("File %q", file)
+	if frame.LineNo != nil {
+		location += fmt.Sprintf(", line %d", *frame.LineNo)
+	}
+	if frame.ColNo != nil {
+		location += fmt.Sprintf(", column %d", *frame.ColNo)
+	}
+	function := frame.Function
+	if function == "" {
+		function = "<unknown>"
+	}
+	fmt.Fprintf(f.writer, "  %s\n", f.colors.frame(location+", in "+function, inApp))
+
+	f.writeContext(frame)
+	if f.vars {
+		f.writeVars(frame.Vars)
+	}
+}
+
+// writeContext writes the source lines around a frame, marking its line
+func (f *TraceFormatter) writeContext(frame models.StackFrame) {
+	lines, current := frame.SourceContext()
+	if len(lines) == 0 {
+		return
+	}
+	if lines[len(lines)-1].LineNo == 0 {
+		// Without line numbers, only the frame's own line is meaningful
+		if current >= 0 {
+			fmt.Fprintf(f.writer, "    %s\n", strings.TrimSpace(lines[current].Code))
+		}
+		return
+	}
+
+	width := len(strconv.Itoa(lines[len(lines)-1].LineNo))
+	for i, line := range lines {
+		if i == current {
+			fmt.Fprintf(f.writer, "%s\n", f.colors.heading(fmt.Sprintf("  > %*d | %s", width, line.LineNo, line.Code)))
+			continue
+		}
+		fmt.Fprintf(f.writer, "    %*d | %s\n", width, line.LineNo, line.Code)
+	}
+}
+
+// writeVars writes the local variables of a frame, sorted by name
+func (f *TraceFormatter) writeVars(vars map[string]interface{}) {
+	if len(vars) == 0 {
+		return
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(f.writer, "    Locals:\n")
+	for _, name := range names {
+		fmt.Fprintf(f.writer, "      %s = %s\n", name, formatFieldValue(filter.ToJSONValue(vars[name])))
+	}
+}
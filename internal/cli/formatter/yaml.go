@@ -0,0 +1,161 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sentire/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter outputs data as YAML. Records are converted through their
+// JSON form, so keys are the JSON field names and keep the same order.
+type YAMLFormatter struct {
+	writer io.Writer
+
+	records int
+}
+
+// NewYAMLFormatter creates a new YAML formatter
+func NewYAMLFormatter(writer io.Writer) *YAMLFormatter {
+	return &YAMLFormatter{writer: writer}
+}
+
+// FormatEvent formats a single event as YAML
+func (f *YAMLFormatter) FormatEvent(event *models.Event) error {
+	return f.FormatGeneric(event)
+}
+
+// FormatEvents formats multiple events as YAML
+func (f *YAMLFormatter) FormatEvents(events []models.Event) error {
+	return f.FormatGeneric(events)
+}
+
+// FormatIssue formats a single issue as YAML
+func (f *YAMLFormatter) FormatIssue(issue *models.Issue) error {
+	return f.FormatGeneric(issue)
+}
+
+// FormatIssues formats multiple issues as YAML
+func (f *YAMLFormatter) FormatIssues(issues []models.Issue) error {
+	return f.FormatGeneric(issues)
+}
+
+// FormatProject formats a single project as YAML
+func (f *YAMLFormatter) FormatProject(project *models.Project) error {
+	return f.FormatGeneric(project)
+}
+
+// FormatProjects formats multiple projects as YAML
+func (f *YAMLFormatter) FormatProjects(projects []models.Project) error {
+	return f.FormatGeneric(projects)
+}
+
+// FormatOrgStats formats organization stats as YAML
+func (f *YAMLFormatter) FormatOrgStats(stats *models.OrganizationStats) error {
+	return f.FormatGeneric(stats)
+}
+
+// FormatGeneric formats any data as a YAML document
+func (f *YAMLFormatter) FormatGeneric(data interface{}) error {
+	node, err := toYAMLNode(data)
+	if err != nil {
+		return err
+	}
+	return f.encode(node)
+}
+
+// Begin starts a streamed YAML sequence
+func (f *YAMLFormatter) Begin() error {
+	f.records = 0
+	return nil
+}
+
+// Record writes one element of a streamed YAML sequence, formatted the
+// same way FormatGeneric formats an element of a whole slice
+func (f *YAMLFormatter) Record(item interface{}) error {
+	node, err := toYAMLNode(item)
+	if err != nil {
+		return err
+	}
+	f.records++
+	return f.encode(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{node}})
+}
+
+// End finishes a streamed YAML sequence; an empty one is written as []
+func (f *YAMLFormatter) End() error {
+	if f.records == 0 {
+		_, err := io.WriteString(f.writer, "[]\n")
+		return err
+	}
+	return nil
+}
+
+func (f *YAMLFormatter) encode(node *yaml.Node) error {
+	encoder := yaml.NewEncoder(f.writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// toYAMLNode converts data to a YAML node through its JSON encoding, which
+// keeps the key order that maps would lose
+func toYAMLNode(data interface{}) (*yaml.Node, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decodeYAMLNode(decoder)
+}
+
+func decodeYAMLNode(decoder *json.Decoder) (*yaml.Node, error) {
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for decoder.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			value, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, value)
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!int"
+		if _, err := t.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
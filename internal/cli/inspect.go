@@ -4,19 +4,20 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
-	"sentire/internal/api"
 	"sentire/internal/cli/formatter"
 	"sentire/internal/client"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var inspectCmd = &cobra.Command{
-	Use:   "inspect <url>",
-	Short: "Inspect a Sentry issue from its URL",
-	Long:  "Parse a Sentry issue URL and display the recommended event with full debugging details",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runInspect,
+	Use:         "inspect <url>",
+	Short:       "Inspect a Sentry issue from its URL",
+	Long:        "Parse a Sentry issue URL and display the recommended event with full debugging details. Accepts sentry.io organization subdomains as well as path-style URLs (https://<host>/organizations/<org>/issues/<id>/) from regional or self-hosted instances",
+	Args:        cobra.ExactArgs(1),
+	RunE:        runInspect,
+	Annotations: map[string]string{offlineAnnotation: "true"},
 }
 
 func init() {
@@ -27,9 +28,34 @@ func init() {
 type SentryURLParts struct {
 	Organization string
 	IssueID      string
+	// BaseURL is the Sentry instance the URL belongs to. It is empty for
+	// plain sentry.io URLs, where the configured instance is used.
+	BaseURL string
+	// Region is set when BaseURL is a sentry.io region, which is safe to
+	// switch to without being configured
+	Region bool
 }
 
-// parseSentryURL extracts organization and issue ID from a Sentry URL
+var (
+	sentrySubdomainRegex = regexp.MustCompile(`^([^.]+)\.(?:([a-z]{2})\.)?sentry\.io$`)
+	orgIssuePathRegex    = regexp.MustCompile(`^(.*?)/organizations/([^/]+)/issues/(\d+)/?`)
+	issuePathRegex       = regexp.MustCompile(`/issues/(\d+)/?`)
+)
+
+// sentryRegions lists the region subdomains of sentry.io that host their own API
+var sentryRegions = map[string]bool{
+	"us": true,
+	"de": true,
+}
+
+// parseSentryURL extracts organization and issue ID from a Sentry URL.
+// Supported formats:
+//
+//	https://orgname.sentry.io/issues/123/
+//	https://orgname.de.sentry.io/issues/123/
+//	https://sentry.io/organizations/orgname/issues/123/
+//	https://de.sentry.io/organizations/orgname/issues/123/
+//	https://sentry.example.com/organizations/orgname/issues/123/
 func parseSentryURL(rawURL string) (*SentryURLParts, error) {
 	// Parse the URL
 	parsedURL, err := url.Parse(rawURL)
@@ -37,30 +63,72 @@ func parseSentryURL(rawURL string) (*SentryURLParts, error) {
 		return nil, fmt.Errorf("invalid URL format: %w", err)
 	}
 
+	host := parsedURL.Hostname()
+	origin := parsedURL.Scheme + "://" + parsedURL.Host
+
+	// Path-style URLs carry the organization in the path and work for any host
+	if matches := orgIssuePathRegex.FindStringSubmatch(parsedURL.Path); matches != nil {
+		parts := &SentryURLParts{
+			Organization: matches[2],
+			IssueID:      matches[3],
+		}
+
+		switch {
+		case host == "sentry.io":
+			// Default instance
+		case isSentryIOHost(host):
+			subdomain := strings.TrimSuffix(host, ".sentry.io")
+			if sentryRegions[subdomain] {
+				parts.BaseURL = "https://" + host
+				parts.Region = true
+			}
+		default:
+			// Self-hosted instance, possibly served under a path prefix
+			parts.BaseURL = origin + matches[1]
+		}
+
+		return parts, nil
+	}
+
 	// Extract organization from subdomain
-	// Expected format: https://orgname.sentry.io/...
-	host := parsedURL.Host
-	sentryRegex := regexp.MustCompile(`^([^.]+)\.sentry\.io$`)
-	matches := sentryRegex.FindStringSubmatch(host)
+	// Expected format: https://orgname.sentry.io/... or https://orgname.<region>.sentry.io/...
+	matches := sentrySubdomainRegex.FindStringSubmatch(host)
 	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid Sentry URL: expected format https://orgname.sentry.io/...")
+		return nil, fmt.Errorf("invalid Sentry URL: expected format https://orgname.sentry.io/... or https://<host>/organizations/<org>/issues/<id>/")
+	}
+	parts := &SentryURLParts{Organization: matches[1]}
+	if region := matches[2]; region != "" {
+		if !sentryRegions[region] {
+			return nil, fmt.Errorf("unknown sentry.io region %q", region)
+		}
+		parts.BaseURL = "https://" + region + ".sentry.io"
+		parts.Region = true
 	}
-	organization := matches[1]
 
 	// Extract issue ID from path
 	// Expected format: /issues/123456789/
-	path := parsedURL.Path
-	issueRegex := regexp.MustCompile(`/issues/(\d+)/?`)
-	issueMatches := issueRegex.FindStringSubmatch(path)
+	issueMatches := issuePathRegex.FindStringSubmatch(parsedURL.Path)
 	if len(issueMatches) < 2 {
 		return nil, fmt.Errorf("invalid issue URL: expected format /issues/<issue_id>/")
 	}
-	issueID := issueMatches[1]
+	parts.IssueID = issueMatches[1]
 
-	return &SentryURLParts{
-		Organization: organization,
-		IssueID:      issueID,
-	}, nil
+	return parts, nil
+}
+
+// isSentryIOAPI reports whether baseURL is the API of sentry.io or of one
+// of its regions
+func isSentryIOAPI(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	if host == "sentry.io" {
+		return true
+	}
+	region, ok := strings.CutSuffix(host, ".sentry.io")
+	return ok && sentryRegions[region]
 }
 
 func runInspect(cmd *cobra.Command, args []string) error {
@@ -76,16 +144,37 @@ func runInspect(cmd *cobra.Command, args []string) error {
 		return NewInvalidInputError(fmt.Sprintf("failed to parse Sentry URL: %v", err))
 	}
 
-	// Create API client
-	c, err := client.NewClient()
+	if err := validateOrgSlug(parts.Organization); err != nil {
+		return err
+	}
+
+	source, c, err := newIssueSource(cmd)
 	if err != nil {
 		return err
 	}
 
-	eventsAPI := api.NewEventsAPI(c)
+	// Talk to the instance the URL points at, unless --url says otherwise.
+	// The API token is only sent to the configured instance, or to another
+	// sentry.io region when sentry.io is the configured instance; never to
+	// a host taken from the URL alone.
+	if c != nil && parts.BaseURL != "" && !cmd.Flags().Changed("url") {
+		baseURL, err := client.APIBaseURL(parts.BaseURL)
+		if err != nil {
+			return NewInvalidInputError(err.Error())
+		}
+		if baseURL != c.BaseURL {
+			if !parts.Region {
+				return NewInvalidInputError(fmt.Sprintf("%s is not the configured Sentry instance; pass --url or select a --profile for it", parts.BaseURL))
+			}
+			if !isSentryIOAPI(c.BaseURL) {
+				return NewInvalidInputError(fmt.Sprintf("%s is for a different Sentry instance than the configured %s; pass --url or select a --profile for it", sentryURL, c.BaseURL))
+			}
+			c.BaseURL = baseURL
+		}
+	}
 
 	// Get the recommended event for the issue
-	event, err := eventsAPI.GetIssueEvent(parts.Organization, parts.IssueID, "recommended", nil)
+	event, err := source.GetIssueEvent(cmd.Context(), parts.Organization, parts.IssueID, "recommended", nil)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve issue event: %w", err)
 	}
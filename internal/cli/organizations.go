@@ -3,7 +3,6 @@ package cli
 import (
 	"sentire/internal/api"
 	"sentire/internal/cli/formatter"
-	"sentire/internal/client"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +17,7 @@ var listOrgProjectsCmd = &cobra.Command{
 	Use:   "list-projects <organization>",
 	Short: "List projects for an organization",
 	Long:  "Retrieve a list of projects for a specific organization",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.RangeArgs(0, 1),
 	RunE:  runListOrgProjects,
 }
 
@@ -26,7 +25,7 @@ var getOrgStatsCmd = &cobra.Command{
 	Use:   "stats <organization>",
 	Short: "Get organization statistics",
 	Long:  "Retrieve event statistics for an organization",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.RangeArgs(0, 1),
 	RunE:  runGetOrgStats,
 }
 
@@ -37,7 +36,7 @@ func init() {
 	orgCmd.AddCommand(getOrgStatsCmd)
 
 	// Flags for list-projects command
-	listOrgProjectsCmd.Flags().Bool("all", false, "Fetch all pages")
+	addPaginationFlags(listOrgProjectsCmd)
 
 	// Flags for stats command
 	getOrgStatsCmd.Flags().String("field", "sum(quantity)", "Field to query: sum(quantity) or sum(times_seen)")
@@ -49,60 +48,47 @@ func init() {
 	getOrgStatsCmd.Flags().StringSlice("category", nil, "Filter by event categories")
 	getOrgStatsCmd.Flags().StringSlice("outcome", nil, "Filter by event outcomes")
 	getOrgStatsCmd.Flags().StringSlice("reason", nil, "Filter by event reasons")
-	getOrgStatsCmd.Flags().Bool("download", false, "Download response as CSV")
+	getOrgStatsCmd.Flags().Bool("download", false, "Write the CSV export generated by Sentry instead of formatted output")
 }
 
 func runListOrgProjects(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug := args[0]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
 
 	orgAPI := api.NewOrganizationsAPI(c)
 
-	fetchAll, _ := cmd.Flags().GetBool("all")
-
-	var allProjects []interface{}
-	cursor := ""
-
-	for {
-		opts := &api.ListProjectsOptions{}
-		if cursor != "" {
-			opts.Cursor = cursor
-		}
-
-		projects, pagination, err := orgAPI.ListProjects(orgSlug, opts)
-		if err != nil {
-			return err
-		}
-
-		for _, project := range projects {
-			allProjects = append(allProjects, project)
-		}
-
-		if !fetchAll || pagination == nil || !pagination.HasNext {
-			break
-		}
-		cursor = pagination.NextCursor
+	pager, err := newPaginator(cmd)
+	if err != nil {
+		return err
 	}
 
-	return formatter.Output(cmd, allProjects)
+	return outputPages(cmd, orgAPI.IterProjects(cmd.Context(), orgSlug, nil, pager), pager)
 }
 
 func runGetOrgStats(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug := args[0]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
@@ -137,11 +123,17 @@ func runGetOrgStats(cmd *cobra.Command, args []string) error {
 	if reasons, _ := cmd.Flags().GetStringSlice("reason"); len(reasons) > 0 {
 		opts.Reason = reasons
 	}
+	// --download writes Sentry's own CSV export as is
 	if download, _ := cmd.Flags().GetBool("download"); download {
-		opts.Download = true
+		data, err := orgAPI.DownloadStats(cmd.Context(), orgSlug, opts)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
 	}
 
-	stats, err := orgAPI.GetStats(orgSlug, opts)
+	stats, err := orgAPI.GetStats(cmd.Context(), orgSlug, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"reflect"
+	"sentire/internal/cli/formatter"
+	"sentire/internal/client"
+	"sentire/internal/filter"
+
+	"github.com/spf13/cobra"
+)
+
+// addPaginationFlags registers the flags shared by list commands
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Fetch all pages")
+	cmd.Flags().Int("max-items", 0, "Stop after this many results, fetching further pages as needed")
+	cmd.Flags().Int("max-pages", 0, "Stop after this many pages")
+	cmd.Flags().String("cursor", "", "Resume from the cursor reported by a previous run")
+	cmd.Flags().String("where", "", `Keep only results matching an expression, e.g. 'level == "error" && userCount > 10'`)
+}
+
+// newPaginator builds a paginator from the pagination flags. Only the first
+// page is fetched unless --all, --max-items or --max-pages is given.
+func newPaginator(cmd *cobra.Command) (*client.Paginator, error) {
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	if maxItems < 0 {
+		return nil, NewInvalidInputError(fmt.Sprintf("invalid --max-items: %d (must be >= 0)", maxItems))
+	}
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+	if maxPages < 0 {
+		return nil, NewInvalidInputError(fmt.Sprintf("invalid --max-pages: %d (must be >= 0)", maxPages))
+	}
+	cursor, _ := cmd.Flags().GetString("cursor")
+
+	fetchAll, _ := cmd.Flags().GetBool("all")
+	if !fetchAll && maxItems == 0 && maxPages == 0 {
+		maxPages = 1
+	}
+
+	return &client.Paginator{
+		Cursor:   cursor,
+		MaxPages: maxPages,
+		MaxItems: maxItems,
+	}, nil
+}
+
+// outputPages writes the items of a paginated listing that match --where
+func outputPages[T any](cmd *cobra.Command, seq iter.Seq2[T, error], pager *client.Paginator) error {
+	seq, err := applyWhere(cmd, seq)
+	if err != nil {
+		return err
+	}
+	return writePages(cmd, seq, pager)
+}
+
+// writePages writes the items of a paginated listing. A single page is
+// collected and formatted as a whole; when more pages may follow, each item
+// is streamed to the formatter as its page arrives, so long exports neither
+// buffer every result nor stay silent until the end. The cursor to resume
+// from is reported on stderr whenever more results are available.
+func writePages[T any](cmd *cobra.Command, seq iter.Seq2[T, error], pager *client.Paginator) error {
+	// A --jq program sees the whole listing, so it cannot be streamed
+	jq, _ := cmd.Flags().GetString("jq")
+	if pager.MaxPages == 1 || jq != "" {
+		var results []interface{}
+		for item, err := range seq {
+			if err != nil {
+				reportNextCursor(pager)
+				return err
+			}
+			results = append(results, item)
+		}
+		reportNextCursor(pager)
+		if len(results) == 0 {
+			// An empty list of a known type still gets, e.g., its CSV header
+			return formatter.Output(cmd, []T{})
+		}
+		return formatter.Output(cmd, results)
+	}
+	return streamPages(cmd, seq, pager)
+}
+
+// applyWhere drops the items that do not match the --where expression.
+// Items are filtered after they are fetched, so --max-items and
+// --max-pages bound what is fetched rather than what is written.
+func applyWhere[T any](cmd *cobra.Command, seq iter.Seq2[T, error]) (iter.Seq2[T, error], error) {
+	source, _ := cmd.Flags().GetString("where")
+	if source == "" {
+		return seq, nil
+	}
+
+	expr, err := filter.Parse(source)
+	if err != nil {
+		return nil, NewInvalidInputError(fmt.Sprintf("invalid --where: %v", err))
+	}
+
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && !expr.Match(item) {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}, nil
+}
+
+// streamPages writes each item of seq as it is read. When the listing
+// fails partway, the output written so far is still terminated so that it
+// stays well-formed, and the error is returned; when it fails before the
+// first item, nothing is written.
+func streamPages[T any](cmd *cobra.Command, seq iter.Seq2[T, error], pager *client.Paginator) error {
+	f, err := formatter.NewFormatter(cmd, nil)
+	if err != nil {
+		return err
+	}
+	if typed, ok := f.(formatter.ItemTyped); ok {
+		typed.SetItemType(reflect.TypeFor[T]())
+	}
+	if err := f.Begin(); err != nil {
+		return err
+	}
+
+	written := 0
+	for item, err := range seq {
+		if err != nil {
+			reportNextCursor(pager)
+			if written == 0 {
+				return err
+			}
+			if endErr := f.End(); endErr != nil {
+				return endErr
+			}
+			fmt.Fprintf(os.Stderr, "Partial output: %d results written before the command stopped\n", written)
+			return err
+		}
+		if err := f.Record(item); err != nil {
+			return err
+		}
+		written++
+	}
+	reportNextCursor(pager)
+	return f.End()
+}
+
+// reportNextCursor tells the user how to continue a paginated listing.
+// After stopping partway through a page there is no cursor to report,
+// since the next page's cursor would skip the rest of the current one.
+func reportNextCursor(pager *client.Paginator) {
+	switch {
+	case pager.Partial:
+		fmt.Fprintf(os.Stderr, "More results available; the listing stopped partway through a page, so it cannot be resumed with --cursor\n")
+	case pager.NextCursor != "":
+		fmt.Fprintf(os.Stderr, "More results available, continue with: --cursor %s\n", pager.NextCursor)
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"sentire/internal/api"
 	"sentire/internal/cli/formatter"
-	"sentire/internal/client"
 
 	"github.com/spf13/cobra"
 )
@@ -26,7 +25,7 @@ var getProjectCmd = &cobra.Command{
 	Use:   "get <organization> <project>",
 	Short: "Get a specific project",
 	Long:  "Retrieve details for a specific project",
-	Args:  cobra.ExactArgs(2),
+	Args:  cobra.RangeArgs(0, 2),
 	RunE:  runGetProject,
 }
 
@@ -37,47 +36,30 @@ func init() {
 	projectsCmd.AddCommand(getProjectCmd)
 
 	// Flags for list command
-	listProjectsCmd.Flags().Bool("all", false, "Fetch all pages")
+	addPaginationFlags(listProjectsCmd)
 }
 
 func runListProjects(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
 
 	projectsAPI := api.NewProjectsAPI(c)
 
-	fetchAll, _ := cmd.Flags().GetBool("all")
-
-	var allProjects []interface{}
-	cursor := ""
-
-	for {
-		opts := &api.ListAllProjectsOptions{}
-		if cursor != "" {
-			opts.Cursor = cursor
-		}
-
-		projects, pagination, err := projectsAPI.ListProjects(opts)
-		if err != nil {
-			return err
-		}
-
-		for _, project := range projects {
-			allProjects = append(allProjects, project)
-		}
-
-		if !fetchAll || pagination == nil || !pagination.HasNext {
-			break
-		}
-		cursor = pagination.NextCursor
+	pager, err := newPaginator(cmd)
+	if err != nil {
+		return err
 	}
 
-	return formatter.Output(cmd, allProjects)
+	return outputPages(cmd, projectsAPI.IterProjects(cmd.Context(), nil, pager), pager)
 }
 
 func runGetProject(cmd *cobra.Command, args []string) error {
+	args, err := resolveArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 	orgSlug, projectSlug := args[0], args[1]
 
 	if err := validateOrgSlug(orgSlug); err != nil {
@@ -87,13 +69,13 @@ func runGetProject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	c, err := client.NewClient()
+	c, err := newClient(cmd)
 	if err != nil {
 		return err
 	}
 
 	projectsAPI := api.NewProjectsAPI(c)
-	project, err := projectsAPI.GetProject(orgSlug, projectSlug)
+	project, err := projectsAPI.GetProject(cmd.Context(), orgSlug, projectSlug)
 	if err != nil {
 		return err
 	}
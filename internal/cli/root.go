@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"sentire/internal/cli/formatter"
+	"sentire/internal/client"
 	"sentire/internal/version"
 )
 
@@ -15,14 +21,30 @@ var rootCmd = &cobra.Command{
 It allows you to query events, issues, projects, and organizations directly from your terminal.
 
 Before using sentire, make sure to set your Sentry API token:
-  export SENTRY_API_TOKEN=your_token_here`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
+  export SENTRY_API_TOKEN=your_token_here
+
+For self-hosted or regional Sentry instances, also set the instance URL:
+  export SENTRY_URL=https://sentry.example.com
+
+Set SENTRY_ORG and SENTRY_PROJECT (or default_org and default_project in the
+config file) to make organization and project arguments optional.`,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	PersistentPreRunE: preRun,
 }
 
-// Execute runs the root command
+// cancelTimeout releases the deadline set by --timeout
+var cancelTimeout context.CancelFunc = func() {}
+
+// Execute runs the root command. Interrupting sentire (Ctrl-C) cancels the
+// command's context, aborting in-flight requests.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	cancelTimeout()
+	stop()
+
+	if err != nil {
 		format, _ := rootCmd.PersistentFlags().GetString("format")
 		writeErrorOutput(os.Stderr, err, format)
 		os.Exit(exitCodeFromError(err))
@@ -31,7 +53,53 @@ func Execute() {
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format: json, ndjson, table, text, markdown")
+	rootCmd.PersistentFlags().StringP("format", "f", "json", "Output format: json, ndjson, yaml, table, text, trace, markdown, html, csv, tsv, template")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Verbose output")
-	rootCmd.PersistentFlags().String("fields", "", "Comma-separated list of fields to include in JSON output")
+	rootCmd.PersistentFlags().String("color", "auto", "Color text, table and template output: auto, always or never (auto colors terminals unless NO_COLOR is set)")
+	rootCmd.PersistentFlags().String("fields", "", "Comma-separated fields to output, as dot paths with [n]/[*] and optional :name (e.g. id,project.slug:project,tags[*].key)")
+	rootCmd.PersistentFlags().String("template", "", "Go template for --format template, e.g. '{{.ShortID}} {{.Title}}'")
+	rootCmd.PersistentFlags().String("template-file", "", "File containing the Go template for --format template")
+	rootCmd.PersistentFlags().Bool("trace-vars", false, "Show the local variables of stack frames in --format trace")
+	rootCmd.PersistentFlags().Bool("trace-raw", false, "Use raw stack traces in --format trace, e.g. minified JavaScript before source maps")
+	rootCmd.PersistentFlags().String("jq", "", "Transform the output with a jq program, e.g. '.[] | {id, title}' (json, ndjson and text formats)")
+	rootCmd.PersistentFlags().String("profile", "", "Configuration profile to use (overrides SENTIRE_PROFILE)")
+	rootCmd.PersistentFlags().Int("max-retries", client.DefaultMaxRetries, "Maximum retries for requests failing with 429 or 5xx (0 disables retries)")
+	rootCmd.PersistentFlags().Int("concurrency", client.DefaultConcurrency, "Maximum parallel requests for commands that fetch details for many results")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Bypass the response cache for this command")
+	rootCmd.PersistentFlags().Bool("refresh", false, "Revalidate cached responses even if they are still fresh")
+	rootCmd.PersistentFlags().Bool("offline", false, "Answer from the local archive instead of the Sentry API (see 'sentire archive pull')")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Maximum time for the whole command, e.g. 30s or 5m (0 means no limit)")
+	rootCmd.PersistentFlags().String("url", "", "Sentry instance URL for self-hosted or regional Sentry (e.g. https://sentry.example.com)")
+}
+
+// preRun applies the global flags that apply to every command
+func preRun(cmd *cobra.Command, args []string) error {
+	if err := checkOffline(cmd); err != nil {
+		return err
+	}
+	if mode, _ := cmd.Flags().GetString("color"); mode != "" {
+		if _, err := formatter.ParseColorMode(mode); err != nil {
+			return NewInvalidInputError(err.Error())
+		}
+	}
+	if source, _ := cmd.Flags().GetString("jq"); source != "" {
+		if _, err := formatter.CompileJQ(source); err != nil {
+			return err
+		}
+	}
+	return applyTimeout(cmd, args)
+}
+
+// applyTimeout bounds the command's context by the --timeout flag
+func applyTimeout(cmd *cobra.Command, args []string) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout < 0 {
+		return NewInvalidInputError(fmt.Sprintf("invalid --timeout: %s (must be >= 0)", timeout))
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		cancelTimeout = cancel
+		cmd.SetContext(ctx)
+	}
+	return nil
 }
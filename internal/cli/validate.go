@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -58,8 +59,25 @@ func validateEventID(id string) error {
 }
 
 func validateInspectURL(rawURL string) error {
-	if !strings.Contains(rawURL, "sentry.io") {
-		return NewInvalidInputError(fmt.Sprintf("invalid Sentry URL: %q (must contain sentry.io)", rawURL))
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewInvalidInputError(fmt.Sprintf("invalid Sentry URL: %q (must be an http(s) URL)", rawURL))
+	}
+	if !isSentryIOHost(u.Hostname()) && !strings.Contains(u.Path, "/organizations/") {
+		return NewInvalidInputError(fmt.Sprintf("invalid Sentry URL: %q (must be a sentry.io URL or contain /organizations/<org>/issues/<id>/)", rawURL))
 	}
 	return nil
 }
+
+func validateSentryURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewInvalidInputError(fmt.Sprintf("invalid Sentry URL: %q (expected format https://sentry.example.com)", rawURL))
+	}
+	return nil
+}
+
+// isSentryIOHost reports whether host is sentry.io or one of its subdomains
+func isSentryIOHost(host string) bool {
+	return host == "sentry.io" || strings.HasSuffix(host, ".sentry.io")
+}
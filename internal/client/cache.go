@@ -0,0 +1,271 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// cacheRule sets how long responses for matching endpoint paths stay fresh
+type cacheRule struct {
+	pattern *regexp.Regexp
+	ttl     time.Duration
+}
+
+// cacheRules are checked in order; the first match wins. Events looked up by
+// ID never change and projects rarely do, while issues and listings change
+// as new events arrive.
+var cacheRules = []cacheRule{
+	{regexp.MustCompile(`/events/[0-9a-fA-F]{32}/$`), 24 * time.Hour},
+	{regexp.MustCompile(`/events/(latest|oldest|recommended)/$`), time.Minute},
+	{regexp.MustCompile(`/stats-summary/$`), 5 * time.Minute},
+	{regexp.MustCompile(`/api/0/(organizations|projects)/$`), time.Hour},
+	{regexp.MustCompile(`/organizations/[^/]+/projects/$`), time.Hour},
+	{regexp.MustCompile(`/projects/[^/]+/[^/]+/$`), time.Hour},
+	{regexp.MustCompile(`/issues/[^/]+/$`), time.Minute},
+}
+
+// defaultCacheTTL applies to endpoints no rule matches, mostly listings
+const defaultCacheTTL = 30 * time.Second
+
+// Cache stores GET responses on disk so repeated lookups do not hit the API.
+// Expired entries that carry an ETag are revalidated with If-None-Match
+// instead of being downloaded again.
+type Cache struct {
+	Dir string
+
+	// Refresh ignores fresh entries, revalidating every cached response
+	Refresh bool
+}
+
+// CacheStats summarizes the contents of a cache directory
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Fresh   int
+	Size    int64
+}
+
+// cacheEntry is a stored response
+type cacheEntry struct {
+	URL      string    `json:"url"`
+	StoredAt time.Time `json:"stored_at"`
+	Expires  time.Time `json:"expires"`
+	ETag     string    `json:"etag,omitempty"`
+	Link     string    `json:"link,omitempty"`
+	Body     []byte    `json:"body"`
+}
+
+// DefaultCacheDir returns the sentire directory under the user cache
+// directory, e.g. ~/.cache/sentire
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "sentire"), nil
+}
+
+// NewCache returns a cache stored in dir
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// cacheTTL returns how long a response for the given URL path stays fresh
+func cacheTTL(path string) time.Duration {
+	for _, rule := range cacheRules {
+		if rule.pattern.MatchString(path) {
+			return rule.ttl
+		}
+	}
+	return defaultCacheTTL
+}
+
+// path returns the file of the entry for url requested with token. Tokens
+// are hashed into the key, so accounts never see each other's responses.
+func (c *Cache) path(url, token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	key := sha256.Sum256([]byte(url + "\x00" + hex.EncodeToString(tokenHash[:])))
+	return filepath.Join(c.Dir, hex.EncodeToString(key[:])+".json")
+}
+
+func (c *Cache) load(path string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *Cache) store(path string, entry *cacheEntry) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(c.Dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Stats reports the number and size of the cached responses
+func (c *Cache) Stats() (*CacheStats, error) {
+	stats := &CacheStats{Dir: c.Dir}
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Size += info.Size()
+		if entry, ok := c.load(file); ok && now.Before(entry.Expires) {
+			stats.Fresh++
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes all cached responses and returns how many were removed
+func (c *Cache) Clear() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	for i, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return i, err
+		}
+	}
+	return len(files), nil
+}
+
+// files lists the entry files in the cache directory
+func (c *Cache) files() ([]string, error) {
+	entries, err := os.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			files = append(files, filepath.Join(c.Dir, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// doCached performs a GET request through c.Cache. Fresh entries are served
+// without a request; stale ones are revalidated when they have an ETag.
+// Failing to write the cache never fails the request.
+func (c *Client) doCached(req *http.Request) (*Response, error) {
+	path := c.Cache.path(req.URL.String(), c.Token)
+	ttl := cacheTTL(req.URL.Path)
+
+	entry, cached := c.Cache.load(path)
+	if cached && !c.Cache.Refresh && time.Now().Before(entry.Expires) {
+		c.logf("Cache hit for %s\n", req.URL.Path)
+		return c.cachedResponse(entry), nil
+	}
+	if cached && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	now := time.Now()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached:
+		resp.Body.Close()
+		c.logf("Cache revalidated for %s\n", req.URL.Path)
+		entry.StoredAt, entry.Expires = now, now.Add(ttl)
+		if err := c.Cache.store(path, entry); err != nil {
+			c.logf("Failed to update cache entry: %v\n", err)
+		}
+		return c.cachedResponse(entry), nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		entry = &cacheEntry{
+			URL:      req.URL.String(),
+			StoredAt: now,
+			Expires:  now.Add(ttl),
+			ETag:     resp.Header.Get("ETag"),
+			Link:     resp.Header.Get("Link"),
+			Body:     body,
+		}
+		if err := c.Cache.store(path, entry); err != nil {
+			c.logf("Failed to write cache entry: %v\n", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// cachedResponse rebuilds a response from a cache entry
+func (c *Client) cachedResponse(entry *cacheEntry) *Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if entry.ETag != "" {
+		header.Set("ETag", entry.ETag)
+	}
+	if entry.Link != "" {
+		header.Set("Link", entry.Link)
+	}
+
+	return &Response{
+		Response: &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader(entry.Body)),
+		},
+		Pagination: c.parseLinkHeader(entry.Link),
+	}
+}
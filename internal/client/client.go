@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,12 +10,17 @@ import (
 	"sentire/internal/config"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	BaseURL   = "https://sentry.io/api/0"
 	UserAgent = "sentire/1.0.0"
+
+	// RequestTimeout bounds each HTTP request when the command has no
+	// overall deadline
+	RequestTimeout = 30 * time.Second
 )
 
 // Client represents the Sentry API client
@@ -23,15 +29,31 @@ type Client struct {
 	HTTPClient *http.Client
 	Token      string
 	RateLimit  *RateLimiter
+	Retry      RetryPolicy
+
+	// Concurrency limits the parallel requests made by FanOut
+	Concurrency int
+
+	// Cache, when set, stores GET responses on disk
+	Cache *Cache
+
+	// Verbose receives diagnostic messages (retries, rate limits) when set
+	Verbose io.Writer
 }
 
-// RateLimiter tracks rate limit information
+// RateLimiter tracks rate limit information and paces requests so the
+// budget advertised by Sentry is not exceeded
 type RateLimiter struct {
 	Limit               int
 	Remaining           int
 	Reset               time.Time
 	ConcurrentLimit     int
 	ConcurrentRemaining int
+
+	mu            sync.Mutex
+	released      chan struct{} // closed and replaced whenever a request finishes
+	inflight      int
+	concurrentCap int // in-flight requests Sentry accepted at the last response
 }
 
 // PaginationInfo contains pagination metadata
@@ -65,30 +87,107 @@ func NewClient() (*Client, error) {
 		return nil, err
 	}
 
+	return NewClientWithConfig(cfg)
+}
+
+// NewClientWithConfig creates a new Sentry API client from an already loaded configuration
+func NewClientWithConfig(cfg *config.Config) (*Client, error) {
+	baseURL, err := APIBaseURL(cfg.SentryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := DefaultRetryPolicy()
+	if cfg.MaxRetries != nil {
+		retry.MaxRetries = *cfg.MaxRetries
+	}
+
 	return &Client{
-		BaseURL: BaseURL,
+		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: RequestTimeout,
 		},
-		Token:     cfg.SentryAPIToken,
-		RateLimit: &RateLimiter{},
+		Token:       cfg.SentryAPIToken,
+		RateLimit:   &RateLimiter{},
+		Retry:       retry,
+		Concurrency: DefaultConcurrency,
 	}, nil
 }
 
-// Do executes an HTTP request and returns the response
+// APIBaseURL converts a Sentry instance URL (e.g. https://sentry.example.com)
+// into the base URL of its REST API. An empty URL resolves to sentry.io.
+func APIBaseURL(sentryURL string) (string, error) {
+	if sentryURL == "" {
+		return BaseURL, nil
+	}
+
+	u, err := url.Parse(sentryURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid Sentry URL %q: expected format https://sentry.example.com", sentryURL)
+	}
+
+	base := strings.TrimSuffix(u.Scheme+"://"+u.Host+u.Path, "/")
+	if !strings.HasSuffix(base, "/api/0") {
+		base += "/api/0"
+	}
+
+	return base, nil
+}
+
+// Do executes an HTTP request and returns the response.
+// Idempotent requests failing with 429 or 5xx are retried according to c.Retry.
+// Waits for rate limits and between retries end early when the request's
+// context is canceled.
 func (c *Client) Do(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+
 	// Set required headers
 	req.Header.Set("Authorization", "Bearer "+c.Token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", UserAgent)
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("http request failed: %w", err)
-	}
+	var resp *http.Response
+	for retries := 0; ; retries++ {
+		if err := c.waitForRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		var err error
+		resp, err = c.HTTPClient.Do(req)
+		if err != nil {
+			c.RateLimit.release()
+			return nil, fmt.Errorf("http request failed: %w", err)
+		}
 
-	// Parse rate limit headers
-	c.parseRateLimitHeaders(resp)
+		// Parse rate limit headers
+		c.parseRateLimitHeaders(resp)
+		c.RateLimit.release()
+		c.logRateLimit()
+
+		if !c.Retry.ShouldRetry(req, resp, retries) {
+			break
+		}
+
+		delay := c.Retry.Delay(retries, resp)
+		c.logf("Request to %s failed with status %d, retrying in %s (%d/%d)\n",
+			req.URL.Path, resp.StatusCode, delay.Round(time.Millisecond), retries+1, c.Retry.MaxRetries)
+
+		// Drain the body so the connection can be reused
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to reset request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
 
 	// Parse pagination from Link header
 	pagination := c.parseLinkHeader(resp.Header.Get("Link"))
@@ -111,18 +210,21 @@ func (c *Client) Do(req *http.Request) (*Response, error) {
 	return response, nil
 }
 
-// Get performs a GET request
-func (c *Client) Get(endpoint string, params url.Values) (*Response, error) {
+// Get performs a GET request bound to ctx
+func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) (*Response, error) {
 	fullURL := c.BaseURL + endpoint
 	if params != nil {
 		fullURL += "?" + params.Encode()
 	}
 
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	if c.Cache != nil {
+		return c.doCached(req)
+	}
 	return c.Do(req)
 }
 
@@ -137,8 +239,18 @@ func (c *Client) DecodeJSON(resp *Response, v interface{}) error {
 	return nil
 }
 
+// logf writes a diagnostic message when verbose output is enabled
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Verbose != nil {
+		fmt.Fprintf(c.Verbose, format, args...)
+	}
+}
+
 // parseRateLimitHeaders extracts rate limiting information from response headers
 func (c *Client) parseRateLimitHeaders(resp *http.Response) {
+	c.RateLimit.mu.Lock()
+	defer c.RateLimit.mu.Unlock()
+
 	if limit := resp.Header.Get("X-Sentry-Rate-Limit-Limit"); limit != "" {
 		if val, err := strconv.Atoi(limit); err == nil {
 			c.RateLimit.Limit = val
@@ -166,6 +278,8 @@ func (c *Client) parseRateLimitHeaders(resp *http.Response) {
 	if concurrentRemaining := resp.Header.Get("X-Sentry-Rate-Limit-ConcurrentRemaining"); concurrentRemaining != "" {
 		if val, err := strconv.Atoi(concurrentRemaining); err == nil {
 			c.RateLimit.ConcurrentRemaining = val
+			// Requests in flight when the response was produced, plus the slots still free
+			c.RateLimit.concurrentCap = c.RateLimit.inflight + val
 		}
 	}
 }
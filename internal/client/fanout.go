@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultConcurrency is the number of requests FanOut runs in parallel
+// unless configured otherwise
+const DefaultConcurrency = 4
+
+// FanOut applies fn to every item of in, running up to c.Concurrency calls
+// at once, and yields the results in the order of the input. The pool never
+// grows beyond the concurrent request limit Sentry last reported. An error
+// from in or fn is yielded once, after the results that precede it, and
+// ends the iteration; calls still running are then canceled. A nil c runs
+// one call at a time.
+func FanOut[T, R any](ctx context.Context, c *Client, in iter.Seq2[T, error], fn func(ctx context.Context, item T) (R, error)) iter.Seq2[R, error] {
+	type result struct {
+		value R
+		err   error
+	}
+
+	return func(yield func(R, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		next, stop := iter.Pull2(in)
+		defer stop()
+
+		var pending []chan result
+		var inErr error
+		exhausted := false
+
+		for {
+			// Keep the pool full while there is input left
+			for !exhausted && len(pending) < c.workers() {
+				item, err, ok := next()
+				if !ok || err != nil {
+					inErr = err
+					exhausted = true
+					break
+				}
+
+				done := make(chan result, 1)
+				pending = append(pending, done)
+				go func() {
+					value, err := fn(ctx, item)
+					done <- result{value, err}
+				}()
+			}
+
+			if len(pending) == 0 {
+				if inErr != nil {
+					var zero R
+					yield(zero, inErr)
+				}
+				return
+			}
+
+			r := <-pending[0]
+			pending = pending[1:]
+			if r.err != nil {
+				yield(r.value, r.err)
+				return
+			}
+			if !yield(r.value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// workers returns how many FanOut calls may run at once. Without a client,
+// as when reading from a local archive, calls run one at a time.
+func (c *Client) workers() int {
+	if c == nil {
+		return 1
+	}
+
+	n := c.Concurrency
+	if n < 1 {
+		n = 1
+	}
+
+	c.RateLimit.mu.Lock()
+	limit := c.RateLimit.ConcurrentLimit
+	c.RateLimit.mu.Unlock()
+
+	if limit > 0 && n > limit {
+		n = limit
+	}
+	return n
+}
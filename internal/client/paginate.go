@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFunc fetches the page of results starting at cursor. An empty cursor
+// requests the first page.
+type PageFunc[T any] func(ctx context.Context, cursor string) ([]T, *PaginationInfo, error)
+
+// Paginator controls how far Paginate walks a cursor-paginated endpoint and
+// records where it stopped, so an export can be resumed later
+type Paginator struct {
+	// Cursor is the cursor of the first page to fetch; empty starts at the beginning
+	Cursor string
+	// MaxPages limits the number of pages fetched; 0 means no limit
+	MaxPages int
+	// MaxItems limits the number of items yielded; 0 means no limit
+	MaxItems int
+
+	// NextCursor is set during iteration to the cursor of the page following
+	// the last one fetched, or of the page whose fetch failed. It is empty
+	// once the last page has been fetched. Items left unread on a page when
+	// iteration stops partway through it are not covered by NextCursor.
+	NextCursor string
+	// Partial is set when iteration stopped partway through the last page
+	// fetched, in which case resuming from NextCursor would skip the items
+	// left unread on it
+	Partial bool
+	// Pages counts the pages fetched
+	Pages int
+}
+
+// Paginate returns an iterator over all items of a paginated endpoint,
+// fetching pages with fetch as the iteration proceeds and following the
+// Link header cursors until the results or the limits of p are exhausted.
+// A fetch error is yielded once, with a zero item, and ends the iteration.
+func Paginate[T any](ctx context.Context, p *Paginator, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := p.Cursor
+		items := 0
+		p.Partial = false
+
+		for p.MaxPages <= 0 || p.Pages < p.MaxPages {
+			page, info, err := fetch(ctx, cursor)
+			if err != nil {
+				p.NextCursor = cursor
+				var zero T
+				yield(zero, err)
+				return
+			}
+			p.Pages++
+
+			p.NextCursor = ""
+			if info != nil && info.HasNext {
+				p.NextCursor = info.NextCursor
+			}
+
+			for i, item := range page {
+				if p.MaxItems > 0 && items >= p.MaxItems {
+					p.Partial = true
+					return
+				}
+				items++
+				if !yield(item, nil) {
+					p.Partial = i < len(page)-1
+					return
+				}
+			}
+
+			if p.NextCursor == "" || (p.MaxItems > 0 && items >= p.MaxItems) {
+				return
+			}
+			cursor = p.NextCursor
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// waitForRateLimit blocks until the rate limiter allows another request to be
+// sent, then reserves a slot for it. The slot must be given back with release.
+// It returns the context's error if ctx is done before a slot is free.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		delay, wait := c.RateLimit.acquire()
+		switch {
+		case delay > 0:
+			c.logf("Rate limit exhausted, waiting %s until reset\n", delay.Round(time.Millisecond))
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+		case wait != nil:
+			select {
+			case <-wait:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// sleep pauses for d, returning early with the context's error if ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire reserves a request slot. When no slot is available it returns
+// either how long to wait for the rate limit window to reset, or a channel
+// that is closed once an in-flight request completes.
+func (r *RateLimiter) acquire() (time.Duration, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Wait for the window to reset once the request budget is spent
+	if r.Limit > 0 && r.Remaining <= 0 {
+		if delay := time.Until(r.Reset); delay > 0 {
+			return delay, nil
+		}
+	}
+
+	// Never exceed the concurrent request budget; a single request is always allowed
+	if r.inflight > 0 {
+		full := r.ConcurrentLimit > 0 && r.inflight >= r.ConcurrentLimit
+		if full || (r.concurrentCap > 0 && r.inflight >= r.concurrentCap) {
+			if r.released == nil {
+				r.released = make(chan struct{})
+			}
+			return 0, r.released
+		}
+	}
+
+	r.inflight++
+	if r.Limit > 0 && r.Remaining > 0 {
+		// Spend the budget optimistically until the response reports the real value
+		r.Remaining--
+	}
+	return 0, nil
+}
+
+// release frees a slot reserved by acquire and wakes up waiting requests
+func (r *RateLimiter) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inflight > 0 {
+		r.inflight--
+	}
+	if r.released != nil {
+		close(r.released)
+		r.released = nil
+	}
+}
+
+// logRateLimit reports the remaining rate limit budget in verbose mode
+func (c *Client) logRateLimit() {
+	if c.Verbose == nil {
+		return
+	}
+
+	c.RateLimit.mu.Lock()
+	limit, remaining, reset := c.RateLimit.Limit, c.RateLimit.Remaining, c.RateLimit.Reset
+	concurrentLimit, concurrentRemaining := c.RateLimit.ConcurrentLimit, c.RateLimit.ConcurrentRemaining
+	c.RateLimit.mu.Unlock()
+
+	if limit == 0 {
+		return
+	}
+
+	c.logf("Rate limit: %d/%d requests remaining, resets at %s", remaining, limit, reset.Format("15:04:05"))
+	if concurrentLimit > 0 {
+		c.logf(" (concurrent: %d/%d)", concurrentRemaining, concurrentLimit)
+	}
+	c.logf("\n")
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings
+const (
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = 500 * time.Millisecond
+	DefaultMaxDelay   = 30 * time.Second
+)
+
+// RetryPolicy controls how requests that fail with a rate limit or server
+// error are retried
+type RetryPolicy struct {
+	MaxRetries int           // Number of retries after the first attempt; 0 disables retries
+	BaseDelay  time.Duration // Backoff before the first retry, doubled on every further retry
+	MaxDelay   time.Duration // Upper bound for every delay, including server hints
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultBaseDelay,
+		MaxDelay:   DefaultMaxDelay,
+	}
+}
+
+// ShouldRetry reports whether a request that received resp should be retried
+// after the given number of retries have already been made. A server asking
+// to wait longer than MaxDelay is not retried, since retrying sooner would
+// only be rejected again.
+func (p RetryPolicy) ShouldRetry(req *http.Request, resp *http.Response, retries int) bool {
+	if retries >= p.MaxRetries || !isIdempotent(req) {
+		return false
+	}
+	if delay, ok := serverDelay(resp); ok && p.MaxDelay > 0 && delay > p.MaxDelay {
+		return false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+// Delay returns how long to wait before the next retry. Server hints from the
+// Retry-After and X-Sentry-Rate-Limit-Reset headers are honored; otherwise an
+// exponential backoff with jitter is used. Either way the delay is capped by
+// MaxDelay.
+func (p RetryPolicy) Delay(retries int, resp *http.Response) time.Duration {
+	if delay, ok := serverDelay(resp); ok {
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return delay
+	}
+
+	delay := p.BaseDelay << retries
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait at least half the backoff, randomize the rest
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// serverDelay returns the wait requested by the Retry-After header or, for
+// rate limited requests, the X-Sentry-Rate-Limit-Reset header
+func serverDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		return delay, true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if reset := resp.Header.Get("X-Sentry-Rate-Limit-Reset"); reset != "" {
+			if val, err := strconv.ParseInt(reset, 10, 64); err == nil {
+				if delay := time.Until(time.Unix(val, 0)); delay > 0 {
+					return delay, true
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			seconds = 0
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether req can safely be sent again
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	// A request body can only be replayed if it can be recreated
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
@@ -2,11 +2,15 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 )
 
+// DefaultSentryURL is the Sentry instance used when no URL is configured
+const DefaultSentryURL = "https://sentry.io"
+
 // AuthError represents an authentication configuration error
 type AuthError struct {
 	Message string
@@ -18,39 +22,250 @@ func (e *AuthError) Error() string {
 
 // Config holds the application configuration
 type Config struct {
-	SentryAPIToken string `json:"sentry_api_token"`
+	SentryAPIToken string `json:"sentry_api_token,omitempty"`
+	// CredentialHelper is a command that prints the API token, used instead
+	// of storing the token in the config file
+	CredentialHelper string `json:"credential_helper,omitempty"`
+	SentryURL        string `json:"sentry_url,omitempty"`
+	DefaultOrg       string `json:"default_org,omitempty"`
+	DefaultProject   string `json:"default_project,omitempty"`
+	MaxRetries       *int   `json:"max_retries,omitempty"`
+	// Cache enables the on-disk response cache
+	Cache bool `json:"cache,omitempty"`
+
+	// DefaultProfile is the profile used when none is selected explicitly
+	DefaultProfile string              `json:"default_profile,omitempty"`
+	Profiles       map[string]*Profile `json:"profiles,omitempty"`
+
+	// Profile is the name of the profile the configuration was resolved from
+	Profile string `json:"-"`
+
+	// Sources records where each resolved setting came from, keyed by config
+	// key (for example KeySentryAPIToken). Unset settings have no entry.
+	Sources map[string]string `json:"-"`
+
+	// TokenSource provides the API token when the winning setting is a
+	// credential helper rather than a literal token
+	TokenSource TokenSource `json:"-"`
+
+	// Warnings lists settings that were found but ignored, for the caller
+	// to report
+	Warnings []string `json:"-"`
+}
+
+// Profile holds the settings for one Sentry account or instance.
+// Settings left empty fall back to the top-level values of the config file.
+type Profile struct {
+	SentryAPIToken   string `json:"sentry_api_token,omitempty"`
+	CredentialHelper string `json:"credential_helper,omitempty"`
+	SentryURL        string `json:"sentry_url,omitempty"`
+	DefaultOrg       string `json:"default_org,omitempty"`
+	DefaultProject   string `json:"default_project,omitempty"`
+}
+
+// LoadOptions controls how the configuration is resolved
+type LoadOptions struct {
+	// Profile selects a named profile, overriding SENTIRE_PROFILE
+	Profile string
+
+	// TokenSource, if set, provides the API token in place of all configured
+	// token sources
+	TokenSource TokenSource
 }
 
 // LoadConfig loads configuration from environment variables or config file
 // Environment variables take precedence over config file values
 func LoadConfig() (*Config, error) {
-	config := &Config{}
-
-	// First, try to load from environment variable
-	if token := os.Getenv("SENTRY_API_TOKEN"); token != "" {
-		config.SentryAPIToken = token
-		return config, nil
-	}
+	return LoadConfigWithOptions(LoadOptions{})
+}
 
-	// If env var is not set, try to load from config file
-	configPath, err := getConfigPath()
+// LoadConfigWithOptions loads configuration and requires an API token to be
+// configured. See ResolveConfig for how settings are resolved.
+func LoadConfigWithOptions(opts LoadOptions) (*Config, error) {
+	config, fileErr, err := resolveConfig(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine config path: %w", err)
+		return nil, err
 	}
 
-	if err := loadFromFile(configPath, config); err != nil {
-		// If config file doesn't exist or has issues, return error about missing token
-		return nil, &AuthError{Message: "SENTRY_API_TOKEN environment variable is required (or configure ~/.config/sentire/config.json)"}
+	if config.SentryAPIToken == "" && config.TokenSource != nil {
+		token, err := config.TokenSource.Token()
+		if err != nil {
+			return nil, err
+		}
+		config.SentryAPIToken = token
 	}
 
 	// Validate that we have a token
 	if config.SentryAPIToken == "" {
+		if config.Profile != "" {
+			return nil, &AuthError{Message: fmt.Sprintf("sentry_api_token is required in profile %q", config.Profile)}
+		}
+		if fileErr != nil {
+			// If config file doesn't exist or has issues, return error about missing token
+			return nil, &AuthError{Message: "SENTRY_API_TOKEN environment variable is required (or set SENTRY_AUTH_TOKEN, configure ~/.config/sentire/config.json or ~/.sentryclirc)"}
+		}
 		return nil, &AuthError{Message: "SENTRY_API_TOKEN is required in config file"}
 	}
 
 	return config, nil
 }
 
+// ResolveConfig loads configuration without requiring an API token, for
+// callers that only need defaults such as the organization. A profile is
+// selected from opts.Profile, SENTIRE_PROFILE or default_profile, in that order.
+//
+// Each setting is resolved with the following precedence:
+//  1. a profile selected explicitly (opts.Profile or SENTIRE_PROFILE)
+//  2. environment variables (SENTRY_API_TOKEN, then SENTRY_AUTH_TOKEN,
+//     SENTRY_URL, SENTRY_ORG, SENTRY_PROJECT)
+//  3. the profile named by default_profile
+//  4. top-level config file values
+//  5. the nearest .sentryclirc in the working directory or its parents
+//  6. ~/.sentryclirc
+//
+// The url of a .sentryclirc in the working directory or its parents is
+// ignored, with a warning in Config.Warnings, unless the API token comes
+// from the same file.
+//
+// A credential_helper set at some level counts as that level's token. The
+// helper is not run here; LoadConfigWithOptions runs it when its token wins.
+// The source of each resolved setting is recorded in Config.Sources.
+func ResolveConfig(opts LoadOptions) (*Config, error) {
+	config, _, err := resolveConfig(opts)
+	return config, err
+}
+
+// resolveConfig implements ResolveConfig. It also returns the error, if any,
+// that prevented the config file from being read.
+func resolveConfig(opts LoadOptions) (*Config, error, error) {
+	config := &Config{}
+
+	// Layers are applied from lowest to highest precedence
+	var localRC, urlBeforeLocalRC, urlSourceBeforeLocalRC string
+	for _, rc := range findSentryCLIRCFiles() {
+		if rc.Local {
+			localRC = rc.Path
+			urlBeforeLocalRC, urlSourceBeforeLocalRC = config.SentryURL, config.Sources[KeySentryURL]
+		}
+		config.applyProfile(rc.Settings, rc.Path)
+	}
+
+	var fileErr error
+	configPath, err := getConfigPath()
+	if err != nil {
+		fileErr = fmt.Errorf("failed to determine config path: %w", err)
+	} else {
+		file := &Config{}
+		fileErr = loadFromFile(configPath, file)
+		config.MaxRetries = file.MaxRetries
+		config.Cache = file.Cache
+		config.DefaultProfile = file.DefaultProfile
+		config.Profiles = file.Profiles
+		config.applyProfile(&Profile{
+			SentryAPIToken:   file.SentryAPIToken,
+			CredentialHelper: file.CredentialHelper,
+			SentryURL:        file.SentryURL,
+			DefaultOrg:       file.DefaultOrg,
+			DefaultProject:   file.DefaultProject,
+		}, configPath)
+	}
+
+	profileName, explicit := opts.Profile, true
+	if profileName == "" {
+		profileName = os.Getenv("SENTIRE_PROFILE")
+	}
+	if profileName == "" {
+		profileName, explicit = config.DefaultProfile, false
+	}
+
+	var profile *Profile
+	if profileName != "" {
+		profile = config.Profiles[profileName]
+		if profile == nil {
+			return nil, fileErr, &AuthError{Message: fmt.Sprintf("profile %q not found (configure it under \"profiles\" in ~/.config/sentire/config.json)", profileName)}
+		}
+		config.Profile = profileName
+	}
+	profileSource := fmt.Sprintf("profile %q in %s", profileName, configPath)
+
+	if !explicit {
+		config.applyProfile(profile, profileSource)
+	}
+	config.applyEnv(KeySentryAPIToken, &config.SentryAPIToken, "SENTRY_AUTH_TOKEN")
+	config.applyEnv(KeySentryAPIToken, &config.SentryAPIToken, "SENTRY_API_TOKEN")
+	config.applyEnv(KeySentryURL, &config.SentryURL, "SENTRY_URL")
+	config.applyEnv(KeyDefaultOrg, &config.DefaultOrg, "SENTRY_ORG")
+	config.applyEnv(KeyDefaultProject, &config.DefaultProject, "SENTRY_PROJECT")
+	if explicit {
+		config.applyProfile(profile, profileSource)
+	}
+
+	if opts.TokenSource != nil {
+		config.SentryAPIToken = ""
+		config.TokenSource = opts.TokenSource
+		config.SetSource(KeySentryAPIToken, "token source")
+	}
+
+	// A .sentryclirc in the working directory may come from a cloned
+	// repository, so its URL is only trusted together with its own token;
+	// otherwise it could send the user's token to a host of its choosing
+	if localRC != "" && config.Sources[KeySentryURL] == localRC && config.Sources[KeySentryAPIToken] != localRC {
+		config.Warnings = append(config.Warnings, fmt.Sprintf("ignoring url %s from %s because the API token comes from elsewhere", config.SentryURL, localRC))
+		config.SentryURL = urlBeforeLocalRC
+		if urlSourceBeforeLocalRC != "" {
+			config.SetSource(KeySentryURL, urlSourceBeforeLocalRC)
+		} else {
+			delete(config.Sources, KeySentryURL)
+		}
+	}
+
+	return config, fileErr, nil
+}
+
+// applyProfile overrides config values with the non-empty settings of p,
+// recording source as their origin
+func (c *Config) applyProfile(p *Profile, source string) {
+	if p == nil {
+		return
+	}
+	if p.CredentialHelper != "" {
+		c.SentryAPIToken = ""
+		c.CredentialHelper = p.CredentialHelper
+		c.TokenSource = &CommandTokenSource{Command: p.CredentialHelper}
+		c.SetSource(KeySentryAPIToken, "credential helper in "+source)
+	}
+	c.apply(KeySentryAPIToken, &c.SentryAPIToken, p.SentryAPIToken, source)
+	c.apply(KeySentryURL, &c.SentryURL, p.SentryURL, source)
+	c.apply(KeyDefaultOrg, &c.DefaultOrg, p.DefaultOrg, source)
+	c.apply(KeyDefaultProject, &c.DefaultProject, p.DefaultProject, source)
+}
+
+// applyEnv overrides a config value with the environment variable name, if set
+func (c *Config) applyEnv(key string, field *string, name string) {
+	c.apply(key, field, os.Getenv(name), name+" environment variable")
+}
+
+// apply sets field to value unless value is empty, recording its source.
+// A literal token replaces any credential helper from a lower level.
+func (c *Config) apply(key string, field *string, value, source string) {
+	if value == "" {
+		return
+	}
+	*field = value
+	if key == KeySentryAPIToken {
+		c.TokenSource = nil
+	}
+	c.SetSource(key, source)
+}
+
+// SetSource records where the value of key came from
+func (c *Config) SetSource(key, source string) {
+	if c.Sources == nil {
+		c.Sources = make(map[string]string)
+	}
+	c.Sources[key] = source
+}
+
 // getConfigPath returns the path to the config file
 func getConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -62,6 +277,7 @@ func getConfigPath() (string, error) {
 }
 
 // loadFromFile loads configuration from a JSON file
+// The config is only modified if the whole file parses successfully
 func loadFromFile(path string, config *Config) error {
 	file, err := os.Open(path)
 	if err != nil {
@@ -69,16 +285,42 @@ func loadFromFile(path string, config *Config) error {
 	}
 	defer file.Close()
 
+	var fileConfig Config
 	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(config); err != nil {
+	if err := decoder.Decode(&fileConfig); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	*config = fileConfig
 	return nil
 }
 
-// SaveConfig saves the current configuration to the config file
-// This is mainly for future use when we add more configuration options
+// ConfigPath returns the path of the config file
+func ConfigPath() (string, error) {
+	return getConfigPath()
+}
+
+// ReadConfigFile reads the config file as stored on disk, without applying
+// environment variables or profiles. A missing file yields an empty config.
+func ReadConfigFile() (*Config, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine config path: %w", err)
+	}
+
+	config := &Config{}
+	if err := loadFromFile(configPath, config); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// SaveConfig saves the configuration to the config file.
+// The file is only readable by the current user since it holds API tokens.
 func SaveConfig(config *Config) error {
 	configPath, err := getConfigPath()
 	if err != nil {
@@ -87,19 +329,33 @@ func SaveConfig(config *Config) error {
 
 	// Create directory if it doesn't exist
 	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	file, err := os.Create(configPath)
+	// Write to a temporary file first so a failed write never truncates the config
+	file, err := os.CreateTemp(configDir, ".config-*.json")
 	if err != nil {
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
-	defer file.Close()
+	defer os.Remove(file.Name())
+
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
 	if err := encoder.Encode(config); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), configPath); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
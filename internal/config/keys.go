@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Setting keys that can be managed with `sentire config`
+const (
+	KeySentryAPIToken   = "sentry_api_token"
+	KeyCredentialHelper = "credential_helper"
+	KeySentryURL        = "sentry_url"
+	KeyDefaultOrg       = "default_org"
+	KeyDefaultProject   = "default_project"
+	KeyMaxRetries       = "max_retries"
+	KeyCache            = "cache"
+	KeyDefaultProfile   = "default_profile"
+)
+
+// Keys lists all supported setting keys in display order
+var Keys = []string{
+	KeySentryAPIToken,
+	KeyCredentialHelper,
+	KeySentryURL,
+	KeyDefaultOrg,
+	KeyDefaultProject,
+	KeyMaxRetries,
+	KeyCache,
+	KeyDefaultProfile,
+}
+
+// profileKeys are the settings that can also be set per profile
+var profileKeys = map[string]bool{
+	KeySentryAPIToken:   true,
+	KeyCredentialHelper: true,
+	KeySentryURL:        true,
+	KeyDefaultOrg:       true,
+	KeyDefaultProject:   true,
+}
+
+// KeyError reports an unknown key or a key used in the wrong scope
+type KeyError struct {
+	Message string
+}
+
+func (e *KeyError) Error() string {
+	return e.Message
+}
+
+// IsProfileKey reports whether key can be set inside a profile
+func IsProfileKey(key string) bool {
+	return profileKeys[key]
+}
+
+// Get returns the value of key, from the named profile when profile is not empty.
+// The boolean result reports whether the setting is set.
+func (c *Config) Get(key, profile string) (string, bool, error) {
+	field, err := c.stringField(key, profile, false)
+	if err != nil {
+		return "", false, err
+	}
+	if field != nil {
+		return *field, *field != "", nil
+	}
+
+	switch key {
+	case KeyCache:
+		return strconv.FormatBool(c.Cache), c.Cache, nil
+	default:
+		if c.MaxRetries == nil {
+			return "", false, nil
+		}
+		return strconv.Itoa(*c.MaxRetries), true, nil
+	}
+}
+
+// Set stores value under key, in the named profile when profile is not empty.
+// Profiles are created on demand.
+func (c *Config) Set(key, value, profile string) error {
+	field, err := c.stringField(key, profile, true)
+	if err != nil {
+		return err
+	}
+	if field != nil {
+		*field = value
+		return nil
+	}
+
+	switch key {
+	case KeyCache:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return &KeyError{Message: fmt.Sprintf("invalid value for %s: %q (must be true or false)", key, value)}
+		}
+		c.Cache = enabled
+	default:
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil || maxRetries < 0 {
+			return &KeyError{Message: fmt.Sprintf("invalid value for %s: %q (must be a non-negative integer)", key, value)}
+		}
+		c.MaxRetries = &maxRetries
+	}
+	return nil
+}
+
+// Unset removes key, from the named profile when profile is not empty.
+// Profiles left without settings are removed.
+func (c *Config) Unset(key, profile string) error {
+	field, err := c.stringField(key, profile, false)
+	if err != nil {
+		return err
+	}
+	switch {
+	case field != nil:
+		*field = ""
+	case key == KeyCache:
+		c.Cache = false
+	default:
+		c.MaxRetries = nil
+	}
+
+	if p := c.Profiles[profile]; p != nil && *p == (Profile{}) {
+		delete(c.Profiles, profile)
+	}
+	return nil
+}
+
+// ProfileNames returns the names of all configured profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stringField returns a pointer to the string setting named key, or nil for
+// the non-string settings max_retries and cache. With create set, a missing
+// profile is added to the config.
+func (c *Config) stringField(key, profile string, create bool) (*string, error) {
+	if !isKnownKey(key) {
+		return nil, &KeyError{Message: fmt.Sprintf("unknown config key: %q", key)}
+	}
+
+	if profile == "" {
+		switch key {
+		case KeySentryAPIToken:
+			return &c.SentryAPIToken, nil
+		case KeyCredentialHelper:
+			return &c.CredentialHelper, nil
+		case KeySentryURL:
+			return &c.SentryURL, nil
+		case KeyDefaultOrg:
+			return &c.DefaultOrg, nil
+		case KeyDefaultProject:
+			return &c.DefaultProject, nil
+		case KeyDefaultProfile:
+			return &c.DefaultProfile, nil
+		default:
+			return nil, nil
+		}
+	}
+
+	if !profileKeys[key] {
+		return nil, &KeyError{Message: fmt.Sprintf("config key %q cannot be set per profile", key)}
+	}
+
+	p := c.Profiles[profile]
+	if p == nil {
+		if !create {
+			// Reads and removals on a missing profile see empty values
+			p = &Profile{}
+		} else {
+			if c.Profiles == nil {
+				c.Profiles = make(map[string]*Profile)
+			}
+			p = &Profile{}
+			c.Profiles[profile] = p
+		}
+	}
+
+	switch key {
+	case KeySentryAPIToken:
+		return &p.SentryAPIToken, nil
+	case KeyCredentialHelper:
+		return &p.CredentialHelper, nil
+	case KeySentryURL:
+		return &p.SentryURL, nil
+	case KeyDefaultOrg:
+		return &p.DefaultOrg, nil
+	default:
+		return &p.DefaultProject, nil
+	}
+}
+
+func isKnownKey(key string) bool {
+	for _, k := range Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
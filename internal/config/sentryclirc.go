@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sentryCLIRCName is the file name of sentry-cli's INI configuration
+const sentryCLIRCName = ".sentryclirc"
+
+// sentryCLIRC is a .sentryclirc file found on disk
+type sentryCLIRC struct {
+	Path     string
+	Settings *Profile
+	// Local is set for a file found in the working directory or one of its
+	// parents rather than in the home directory
+	Local bool
+}
+
+// findSentryCLIRCFiles returns the sentry-cli configuration files that apply
+// to the current directory, lowest precedence first: ~/.sentryclirc, then
+// the nearest .sentryclirc in the working directory or one of its parents.
+// Missing or unreadable files are skipped.
+func findSentryCLIRCFiles() []sentryCLIRC {
+	var files []sentryCLIRC
+
+	homePath := ""
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		homePath = filepath.Join(homeDir, sentryCLIRCName)
+		if settings, err := readSentryCLIRC(homePath); err == nil {
+			files = append(files, sentryCLIRC{Path: homePath, Settings: settings})
+		}
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return files
+	}
+	for {
+		path := filepath.Join(dir, sentryCLIRCName)
+		if path == homePath {
+			break
+		}
+		if settings, err := readSentryCLIRC(path); err == nil {
+			files = append(files, sentryCLIRC{Path: path, Settings: settings, Local: true})
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return files
+}
+
+// readSentryCLIRC reads the settings sentire shares with sentry-cli from an
+// INI file: token in [auth], and url, org and project in [defaults]
+func readSentryCLIRC(path string) (*Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	settings := &Profile{}
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch section + "." + key {
+		case "auth.token":
+			settings.SentryAPIToken = value
+		case "defaults.url":
+			settings.SentryURL = strings.TrimSuffix(value, "/")
+		case "defaults.org":
+			settings.DefaultOrg = value
+		case "defaults.project":
+			settings.DefaultProject = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// TokenSource provides a Sentry API token from outside the config file,
+// such as a password manager or a secrets vault
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token
+type StaticTokenSource string
+
+// Token returns the static token
+func (s StaticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
+
+// CommandTokenSource is a TokenSource that runs a credential helper command
+// and reads the token from the first line of its output, in the manner of
+// git credential helpers. The command is run through the shell, so it may
+// contain arguments and pipes, for example "pass show sentry/token" or
+// "op read op://Private/Sentry/credential".
+type CommandTokenSource struct {
+	Command string
+}
+
+// Token runs the credential helper. Its stderr is passed through so helpers
+// can prompt for a passphrase.
+func (s *CommandTokenSource) Token() (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", s.Command)
+	} else {
+		cmd = exec.Command("sh", "-c", s.Command)
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", &AuthError{Message: fmt.Sprintf("credential helper %q failed with exit code %d", s.Command, exitErr.ExitCode())}
+		}
+		return "", &AuthError{Message: fmt.Sprintf("failed to run credential helper %q: %v", s.Command, err)}
+	}
+
+	token, _, _ := strings.Cut(stdout.String(), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", &AuthError{Message: fmt.Sprintf("credential helper %q did not print a token", s.Command)}
+	}
+	return token, nil
+}
@@ -0,0 +1,318 @@
+// Package filter implements the --where expression language, which selects
+// fetched issues, events and projects on the client side.
+//
+// An expression compares fields of a record with literals:
+//
+//	level == "error" && userCount > 10 && tags.browser =~ "Chrome"
+//
+// Fields are the JSON field names of the record, with dots for nested
+// objects and [n] for list elements, e.g. contexts.os.name or
+// exception.values[0].type. Keys containing dots are written as
+// tags["os.name"]. The operators are == != < <= > >= =~ (regular
+// expression match) !~ (no match), combined with && || ! and parentheses.
+// A field on its own is true when it is present and not false, zero or
+// empty.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError reports an expression that cannot be parsed
+type SyntaxError struct {
+	Message string
+	Pos     int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos+1)
+}
+
+// Expr is a parsed --where expression
+type Expr struct {
+	source string
+	root   node
+}
+
+// Parse parses a --where expression
+func Parse(source string) (*Expr, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &SyntaxError{Message: fmt.Sprintf("unexpected %q", tok.text), Pos: tok.pos}
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// String returns the expression as it was written
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Match reports whether record satisfies the expression. record is
+// converted to its JSON representation, so fields are matched by their
+// JSON names.
+func (e *Expr) Match(record interface{}) bool {
+	return truthy(e.root.eval(ToJSONValue(record)))
+}
+
+// ToJSONValue converts a value to the generic form encoding/json decodes
+// into: maps, slices, strings, float64, bool and nil. Maps and slices are
+// always converted, since their elements may be of any type.
+func ToJSONValue(v interface{}) interface{} {
+	switch v.(type) {
+	case string, float64, bool, nil:
+		return v
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// node is an expression tree node, evaluated against a record
+type node interface {
+	eval(record interface{}) interface{}
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n literal) eval(interface{}) interface{} {
+	return n.value
+}
+
+// path looks up a field; each step is a string key or an int index
+type path struct {
+	steps []interface{}
+}
+
+func (n path) eval(record interface{}) interface{} {
+	v := record
+	for i, step := range n.steps {
+		switch s := step.(type) {
+		case string:
+			v = lookupKey(v, s, i == 0)
+		case int:
+			list, ok := v.([]interface{})
+			if !ok || s < 0 || s >= len(list) {
+				return nil
+			}
+			v = list[s]
+		}
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
+
+// lookupKey returns the value of key in v. Lists of {"key", "value"}
+// pairs, as Sentry uses for tags, are looked up by key. At the top level
+// of an event, a field missing from the record falls back to the data of
+// the entry of that type, so exception.values works whether Sentry sent
+// the exception as a field or as an entry.
+func lookupKey(v interface{}, key string, top bool) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if value, ok := x[key]; ok && value != nil {
+			return value
+		}
+		if top {
+			return entryData(x, key)
+		}
+	case []interface{}:
+		for _, item := range x {
+			pair, ok := item.(map[string]interface{})
+			if ok && pair["key"] == key {
+				return pair["value"]
+			}
+		}
+	}
+	return nil
+}
+
+// entryData returns the data of the event entry of the given type
+func entryData(event map[string]interface{}, entryType string) interface{} {
+	entries, _ := event["entries"].([]interface{})
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if ok && entry["type"] == entryType {
+			return entry["data"]
+		}
+	}
+	return nil
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) eval(record interface{}) interface{} {
+	return !truthy(n.operand.eval(record))
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n logical) eval(record interface{}) interface{} {
+	left := truthy(n.left.eval(record))
+	if n.op == "&&" {
+		return left && truthy(n.right.eval(record))
+	}
+	return left || truthy(n.right.eval(record))
+}
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (n comparison) eval(record interface{}) interface{} {
+	left, right := n.left.eval(record), n.right.eval(record)
+	switch n.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+type match struct {
+	negate bool
+	left   node
+	re     *regexp.Regexp
+}
+
+func (n match) eval(record interface{}) interface{} {
+	v := n.left.eval(record)
+	if v == nil {
+		return n.negate
+	}
+	return n.re.MatchString(stringify(v)) != n.negate
+}
+
+// truthy reports whether a value counts as true on its own
+func truthy(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case float64:
+		return x != 0
+	case string:
+		return x != ""
+	case []interface{}:
+		return len(x) > 0
+	case map[string]interface{}:
+		return len(x) > 0
+	}
+	return true
+}
+
+// equal compares two values, treating numeric strings such as an issue's
+// count as numbers when compared with a number
+func equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if c, ok := compare(a, b); ok {
+		return c == 0
+	}
+	if x, ok := a.(bool); ok {
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+	return false
+}
+
+// compare orders two numbers or two strings; ok is false for other values.
+// A numeric string compared with a number is compared as a number.
+func compare(a, b interface{}) (c int, ok bool) {
+	_, numA := a.(float64)
+	_, numB := b.(float64)
+	if numA || numB {
+		x, okA := number(a)
+		y, okB := number(b)
+		if !okA || !okB {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	x, okA := a.(string)
+	y, okB := b.(string)
+	if !okA || !okB {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+// number converts a number or numeric string to a float64
+func number(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case string:
+		f, err := strconv.ParseFloat(x, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// stringify formats a value for regular expression matching
+func stringify(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
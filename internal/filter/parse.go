@@ -0,0 +1,287 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators are matched longest first
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")", "[", "]", "."}
+
+// lex splits an expression into tokens
+func lex(source string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '"' || c == '\'':
+			start := i
+			var b strings.Builder
+			i++
+			for i < len(source) && source[i] != c {
+				if source[i] == '\\' && i+1 < len(source) {
+					i++
+				}
+				b.WriteByte(source[i])
+				i++
+			}
+			if i >= len(source) {
+				return nil, &SyntaxError{Message: "unterminated string", Pos: start}
+			}
+			i++
+			tokens = append(tokens, token{tokenString, b.String(), start})
+
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(source) && source[i+1] >= '0' && source[i+1] <= '9':
+			start := i
+			i++
+			for i < len(source) && (source[i] >= '0' && source[i] <= '9' || source[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, source[start:i], start})
+
+		case isIdentStart(c):
+			start := i
+			for i < len(source) && isIdentPart(source[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, source[start:i], start})
+
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &SyntaxError{Message: fmt.Sprintf("unexpected character %q", c), Pos: i}
+			}
+			tokens = append(tokens, token{tokenOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "end of expression", len(source)}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// parser is a recursive descent parser over the tokens of an expression:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = operand [ op operand ]
+//	operand    = path | string | number | true | false | null | "(" or ")"
+//	path       = ident { "." ident | "[" ( number | string ) "]" }
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the operator op
+func (p *parser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOp && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		tok := p.peek()
+		return &SyntaxError{Message: fmt.Sprintf("expected %q, found %q", op, tok.text), Pos: tok.pos}
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return not{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenOp {
+		return left, nil
+	}
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return comparison{op: tok.text, left: left, right: right}, nil
+
+	case "=~", "!~":
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, &SyntaxError{Message: fmt.Sprintf("%s must be followed by a quoted regular expression", tok.text), Pos: pattern.pos}
+		}
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, &SyntaxError{Message: fmt.Sprintf("invalid regular expression: %v", err), Pos: pattern.pos}
+		}
+		return match{negate: tok.text == "!~", left: left, re: re}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return literal{tok.text}, nil
+
+	case tokenNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Message: fmt.Sprintf("invalid number %q", tok.text), Pos: tok.pos}
+		}
+		return literal{f}, nil
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literal{true}, nil
+		case "false":
+			return literal{false}, nil
+		case "null":
+			return literal{nil}, nil
+		}
+		return p.parsePath(tok)
+
+	case tokenOp:
+		if tok.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	}
+	return nil, &SyntaxError{Message: fmt.Sprintf("unexpected %q", tok.text), Pos: tok.pos}
+}
+
+// parsePath parses the steps following the first field name of a path
+func (p *parser) parsePath(first token) (node, error) {
+	steps := []interface{}{first.text}
+	for {
+		switch {
+		case p.accept("."):
+			tok := p.next()
+			if tok.kind != tokenIdent {
+				return nil, &SyntaxError{Message: fmt.Sprintf("expected a field name after \".\", found %q", tok.text), Pos: tok.pos}
+			}
+			steps = append(steps, tok.text)
+
+		case p.accept("["):
+			tok := p.next()
+			switch tok.kind {
+			case tokenNumber:
+				n, err := strconv.Atoi(tok.text)
+				if err != nil {
+					return nil, &SyntaxError{Message: fmt.Sprintf("invalid index %q", tok.text), Pos: tok.pos}
+				}
+				steps = append(steps, n)
+			case tokenString:
+				steps = append(steps, tok.text)
+			default:
+				return nil, &SyntaxError{Message: fmt.Sprintf("expected an index or quoted key, found %q", tok.text), Pos: tok.pos}
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+
+		default:
+			return path{steps: steps}, nil
+		}
+	}
+}
@@ -0,0 +1,456 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Entry types of Sentry events
+const (
+	EntryException   = "exception"
+	EntryBreadcrumbs = "breadcrumbs"
+	EntryRequest     = "request"
+	EntryThreads     = "threads"
+	EntryMessage     = "message"
+	EntryTemplate    = "template"
+	EntryCSP         = "csp"
+	EntryExpectCT    = "expectct"
+	EntryDebugMeta   = "debugmeta"
+	EntrySpans       = "spans"
+)
+
+// UnmarshalJSON decodes the data of an entry into the typed struct for its
+// type: *Exception, *Breadcrumbs, *Request, *Threads, *LogEntry, *Template,
+// *CSPReport, *ExpectCTReport, *DebugMeta or []Span. Data of other types,
+// or that does not fit its type, is kept as decoded by encoding/json.
+func (e *Entry) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	e.Type = raw.Type
+	e.Data = nil
+	e.raw = nil
+	e.decoded = nil
+	if len(raw.Data) == 0 || string(raw.Data) == "null" {
+		return nil
+	}
+
+	if data := newEntryData(raw.Type); data != nil && json.Unmarshal(raw.Data, data) == nil {
+		e.Data = derefEntryData(data)
+	} else if err := json.Unmarshal(raw.Data, &e.Data); err != nil {
+		return err
+	}
+
+	decoded, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	e.raw, e.decoded = raw.Data, decoded
+	return nil
+}
+
+// MarshalJSON encodes a decoded entry as it was received, so fields the
+// typed structs leave out are kept. Once Data has been changed, Data is
+// encoded instead.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	if e.raw != nil && bytes.Equal(data, e.decoded) {
+		data = e.raw
+	}
+	return json.Marshal(struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}{e.Type, data})
+}
+
+// newEntryData returns a pointer to decode the data of an entry type into
+func newEntryData(entryType string) interface{} {
+	switch entryType {
+	case EntryException:
+		return &Exception{}
+	case EntryBreadcrumbs:
+		return &Breadcrumbs{}
+	case EntryRequest:
+		return &Request{}
+	case EntryThreads:
+		return &Threads{}
+	case EntryMessage:
+		return &LogEntry{}
+	case EntryTemplate:
+		return &Template{}
+	case EntryCSP:
+		return &CSPReport{}
+	case EntryExpectCT:
+		return &ExpectCTReport{}
+	case EntryDebugMeta:
+		return &DebugMeta{}
+	case EntrySpans:
+		return &[]Span{}
+	}
+	return nil
+}
+
+func derefEntryData(data interface{}) interface{} {
+	if spans, ok := data.(*[]Span); ok {
+		return *spans
+	}
+	return data
+}
+
+// entryData returns the data of the event's first entry of a type as a T.
+// Entries built in code rather than decoded may hold generic JSON data,
+// which is converted.
+func entryData[T any](event *Event, entryType string) (T, bool) {
+	var zero T
+	for _, entry := range event.Entries {
+		if entry.Type != entryType {
+			continue
+		}
+		if data, ok := entry.Data.(T); ok {
+			return data, true
+		}
+		b, err := json.Marshal(entry.Data)
+		if err != nil {
+			return zero, false
+		}
+		data := newEntryData(entryType)
+		if data == nil || json.Unmarshal(b, data) != nil {
+			return zero, false
+		}
+		typed, ok := derefEntryData(data).(T)
+		return typed, ok
+	}
+	return zero, false
+}
+
+// Exceptions returns the exceptions of the event in the order Sentry sends
+// them: the root cause first, the exception that was raised last at the end
+func (e *Event) Exceptions() []ExceptionValue {
+	if e.Exception != nil {
+		return e.Exception.Values
+	}
+	if exception, ok := entryData[*Exception](e, EntryException); ok {
+		return exception.Values
+	}
+	return nil
+}
+
+// BreadcrumbValues returns the breadcrumbs recorded before the event,
+// oldest first
+func (e *Event) BreadcrumbValues() []Breadcrumb {
+	if e.Breadcrumbs != nil {
+		return e.Breadcrumbs.Values
+	}
+	if breadcrumbs, ok := entryData[*Breadcrumbs](e, EntryBreadcrumbs); ok {
+		return breadcrumbs.Values
+	}
+	return nil
+}
+
+// HTTPRequest returns the HTTP request the event happened in, or nil
+func (e *Event) HTTPRequest() *Request {
+	if e.Request != nil {
+		return e.Request
+	}
+	request, _ := entryData[*Request](e, EntryRequest)
+	return request
+}
+
+// Threads returns the threads of the event, as sent by native and JVM SDKs,
+// in the order the SDK listed them; see CrashedFirst for display order
+func (e *Event) Threads() []Thread {
+	if threads, ok := entryData[*Threads](e, EntryThreads); ok {
+		return threads.Values
+	}
+	return nil
+}
+
+// LogEntry returns the message entry of the event, or nil
+func (e *Event) LogEntry() *LogEntry {
+	entry, _ := entryData[*LogEntry](e, EntryMessage)
+	return entry
+}
+
+// Template returns the template the event happened in, or nil
+func (e *Event) Template() *Template {
+	template, _ := entryData[*Template](e, EntryTemplate)
+	return template
+}
+
+// CSPReport returns the Content Security Policy report of the event, or nil
+func (e *Event) CSPReport() *CSPReport {
+	report, _ := entryData[*CSPReport](e, EntryCSP)
+	return report
+}
+
+// ExpectCTReport returns the Expect-CT report of the event, or nil
+func (e *Event) ExpectCTReport() *ExpectCTReport {
+	report, _ := entryData[*ExpectCTReport](e, EntryExpectCT)
+	return report
+}
+
+// DebugMeta returns the debug information files of the event, or nil
+func (e *Event) DebugMeta() *DebugMeta {
+	meta, _ := entryData[*DebugMeta](e, EntryDebugMeta)
+	return meta
+}
+
+// Spans returns the spans of a transaction event
+func (e *Event) Spans() []Span {
+	spans, _ := entryData[[]Span](e, EntrySpans)
+	return spans
+}
+
+// Threads contains the threads of an event
+type Threads struct {
+	Values []Thread `json:"values"`
+}
+
+// Thread represents a thread running when the event was captured
+type Thread struct {
+	ID            interface{} `json:"id"` // Can be string or number
+	Name          string      `json:"name,omitempty"`
+	Crashed       bool        `json:"crashed"`
+	Current       bool        `json:"current"`
+	State         string      `json:"state,omitempty"`
+	Stacktrace    *Stacktrace `json:"stacktrace,omitempty"`
+	RawStacktrace *Stacktrace `json:"rawStacktrace,omitempty"`
+}
+
+// CrashedFirst returns the threads in display order: the crashed thread
+// first, then the current thread, then the others in their original order
+func CrashedFirst(threads []Thread) []Thread {
+	rank := func(t Thread) int {
+		switch {
+		case t.Crashed:
+			return 0
+		case t.Current:
+			return 1
+		}
+		return 2
+	}
+	ordered := slices.Clone(threads)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+	return ordered
+}
+
+// LogEntry is the message entry of an event: the formatted message and the
+// format string and parameters it was built from
+type LogEntry struct {
+	Formatted string      `json:"formatted,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Params    interface{} `json:"params,omitempty"`
+}
+
+// Template describes the location of an error in a template, such as a
+// Django template
+type Template struct {
+	Filename    string        `json:"filename,omitempty"`
+	AbsPath     string        `json:"absPath,omitempty"`
+	LineNo      *int          `json:"lineNo,omitempty"`
+	Context     []ContextLine `json:"context,omitempty"`
+	ContextLine string        `json:"contextLine,omitempty"`
+	PreContext  []string      `json:"preContext,omitempty"`
+	PostContext []string      `json:"postContext,omitempty"`
+}
+
+// CSPReport is a Content Security Policy violation report
+type CSPReport struct {
+	DocumentURI        string `json:"document_uri,omitempty"`
+	BlockedURI         string `json:"blocked_uri,omitempty"`
+	EffectiveDirective string `json:"effective_directive,omitempty"`
+	ViolatedDirective  string `json:"violated_directive,omitempty"`
+	OriginalPolicy     string `json:"original_policy,omitempty"`
+	Referrer           string `json:"referrer,omitempty"`
+	Disposition        string `json:"disposition,omitempty"`
+	SourceFile         string `json:"source_file,omitempty"`
+	LineNumber         *int   `json:"line_number,omitempty"`
+	ColumnNumber       *int   `json:"column_number,omitempty"`
+	ScriptSample       string `json:"script_sample,omitempty"`
+	StatusCode         *int   `json:"status_code,omitempty"`
+}
+
+// ExpectCTReport is a Certificate Transparency (Expect-CT) failure report
+type ExpectCTReport struct {
+	DateTime                  string   `json:"date_time,omitempty"`
+	Hostname                  string   `json:"hostname,omitempty"`
+	Port                      *int     `json:"port,omitempty"`
+	EffectiveExpirationDate   string   `json:"effective_expiration_date,omitempty"`
+	ServedCertificateChain    []string `json:"served_certificate_chain,omitempty"`
+	ValidatedCertificateChain []string `json:"validated_certificate_chain,omitempty"`
+	FailureMode               string   `json:"failure_mode,omitempty"`
+	TestReport                *bool    `json:"test_report,omitempty"`
+}
+
+// DebugMeta lists the debug information files (images) needed to
+// symbolicate native stack traces
+type DebugMeta struct {
+	Images  []DebugImage           `json:"images,omitempty"`
+	SDKInfo map[string]interface{} `json:"sdk_info,omitempty"`
+}
+
+// DebugImage is a loaded module or debug information file. Images have
+// many type-specific fields; the common ones are decoded.
+type DebugImage struct {
+	Type        string `json:"type"`
+	CodeFile    string `json:"code_file,omitempty"`
+	CodeID      string `json:"code_id,omitempty"`
+	DebugFile   string `json:"debug_file,omitempty"`
+	DebugID     string `json:"debug_id,omitempty"`
+	ImageAddr   string `json:"image_addr,omitempty"`
+	ImageSize   int64  `json:"image_size,omitempty"`
+	Arch        string `json:"arch,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	ImageVMAddr string `json:"image_vmaddr,omitempty"`
+}
+
+// Span is an operation timed within a transaction event. Timestamps are in
+// seconds since the Unix epoch.
+type Span struct {
+	SpanID         string                 `json:"span_id"`
+	ParentSpanID   string                 `json:"parent_span_id,omitempty"`
+	TraceID        string                 `json:"trace_id,omitempty"`
+	Op             string                 `json:"op,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	StartTimestamp float64                `json:"start_timestamp"`
+	Timestamp      float64                `json:"timestamp"`
+	ExclusiveTime  float64                `json:"exclusive_time,omitempty"`
+	Tags           map[string]string      `json:"tags,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+}
+
+// ContextLine is a numbered line of source code around a stack frame, sent
+// by the events API as a [lineNo, code] pair
+type ContextLine struct {
+	LineNo int
+	Code   string
+}
+
+// UnmarshalJSON decodes a [lineNo, code] pair
+func (c *ContextLine) UnmarshalJSON(b []byte) error {
+	var pair []interface{}
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("context line: expected [lineNo, code], got %s", b)
+	}
+	lineNo, ok := pair[0].(float64)
+	if !ok {
+		return fmt.Errorf("context line: expected a line number, got %v", pair[0])
+	}
+	code, _ := pair[1].(string)
+	c.LineNo, c.Code = int(lineNo), code
+	return nil
+}
+
+// MarshalJSON encodes the line as a [lineNo, code] pair
+func (c ContextLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{c.LineNo, c.Code})
+}
+
+// SourceContext returns the lines of source code around the frame and the
+// index of the frame's own line among them, or -1 if it is not known. The
+// lines come from Context, as the events API sends them, or else from
+// PreContext, ContextLine and PostContext, numbered from LineNo when it is
+// set and with LineNo 0 otherwise.
+func (f StackFrame) SourceContext() ([]ContextLine, int) {
+	if len(f.Context) > 0 {
+		current := -1
+		for i, line := range f.Context {
+			if f.LineNo != nil && line.LineNo == *f.LineNo {
+				current = i
+			}
+		}
+		return f.Context, current
+	}
+	if f.ContextLine == "" {
+		return nil, -1
+	}
+
+	lineNo := 0
+	if f.LineNo != nil {
+		lineNo = *f.LineNo
+	}
+	number := func(offset int) int {
+		if lineNo == 0 {
+			return 0
+		}
+		return lineNo + offset
+	}
+
+	lines := make([]ContextLine, 0, len(f.PreContext)+1+len(f.PostContext))
+	for i, code := range f.PreContext {
+		lines = append(lines, ContextLine{number(i - len(f.PreContext)), code})
+	}
+	lines = append(lines, ContextLine{lineNo, f.ContextLine})
+	for i, code := range f.PostContext {
+		lines = append(lines, ContextLine{number(i + 1), code})
+	}
+	return lines, len(f.PreContext)
+}
+
+// pairsOrMap decodes a JSON object of strings, or a list of [key, value]
+// pairs as the events API sends request headers and cookies
+func pairsOrMap(b json.RawMessage) (map[string]string, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err == nil {
+		return m, nil
+	}
+	var pairs [][]interface{}
+	if err := json.Unmarshal(b, &pairs); err != nil {
+		return nil, err
+	}
+	m = make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			continue
+		}
+		key, _ := pair[0].(string)
+		value, _ := pair[1].(string)
+		m[key] = value
+	}
+	return m, nil
+}
+
+// UnmarshalJSON decodes a request, accepting headers and cookies either as
+// objects or as lists of [key, value] pairs
+func (r *Request) UnmarshalJSON(b []byte) error {
+	type request Request
+	var raw struct {
+		request
+		Headers json.RawMessage `json:"headers"`
+		Cookies json.RawMessage `json:"cookies"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*r = Request(raw.request)
+
+	var err error
+	if r.Headers, err = pairsOrMap(raw.Headers); err != nil {
+		return fmt.Errorf("request headers: %w", err)
+	}
+	if r.Cookies, err = pairsOrMap(raw.Cookies); err != nil {
+		return fmt.Errorf("request cookies: %w", err)
+	}
+	return nil
+}
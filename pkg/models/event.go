@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Event represents a complete Sentry event with all fields
 type Event struct {
@@ -43,10 +46,16 @@ type Event struct {
 	Errors []EventError `json:"errors,omitempty"`
 }
 
-// Entry represents different types of entries in an event (exception, breadcrumbs, request, etc.)
+// Entry represents different types of entries in an event (exception, breadcrumbs, request, etc.).
+// Decoding an entry sets Data to the typed struct for its type; see UnmarshalJSON.
 type Entry struct {
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
+
+	// raw is the data as received, written back by MarshalJSON while
+	// Data still encodes to decoded, the encoding of Data after decoding
+	raw     json.RawMessage
+	decoded []byte
 }
 
 // Exception contains exception information with stack traces
@@ -90,6 +99,7 @@ type StackFrame struct {
 	LineNo          *int                   `json:"lineNo,omitempty"`
 	ColNo           *int                   `json:"colNo,omitempty"`
 	AbsPath         string                 `json:"absPath,omitempty"`
+	Context         []ContextLine          `json:"context,omitempty"`
 	ContextLine     string                 `json:"contextLine,omitempty"`
 	PreContext      []string               `json:"preContext,omitempty"`
 	PostContext     []string               `json:"postContext,omitempty"`
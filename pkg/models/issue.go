@@ -38,6 +38,10 @@ type Issue struct {
 	HasSeen             bool            `json:"hasSeen"`
 	Annotations         interface{}     `json:"annotations,omitempty"` // Can be array or object
 	Activity            []IssueActivity `json:"activity,omitempty"`
+
+	// RecommendedEvent is filled in by list-issues --with-event; it is not
+	// part of the issues API response
+	RecommendedEvent *Event `json:"recommendedEvent,omitempty"`
 }
 
 // IssueProject represents project info in an issue
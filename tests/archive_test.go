@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/archive"
+	"sentire/internal/client"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveLookups(t *testing.T) {
+	a := archive.Open(t.TempDir())
+	ctx := context.Background()
+
+	older := models.Issue{ID: "1", ShortID: "PROJ-1", LastSeen: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := models.Issue{ID: "2", ShortID: "PROJ-2", LastSeen: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}
+	for _, issue := range []models.Issue{older, newer} {
+		if err := a.SaveIssue("my-org", &issue); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+	event := &models.Event{EventID: "0123456789abcdef0123456789abcdef", Title: "Boom"}
+	if err := a.SaveEvent("my-org", "1", "recommended", event); err != nil {
+		t.Fatalf("SaveEvent failed: %v", err)
+	}
+
+	if issue, err := a.GetIssue(ctx, "my-org", "proj-1"); err != nil || issue.ID != "1" {
+		t.Errorf("Expected issue 1 by short ID, got %v (err: %v)", issue, err)
+	}
+	for _, eventID := range []string{"recommended", event.EventID} {
+		if got, err := a.GetIssueEvent(ctx, "my-org", "PROJ-1", eventID, nil); err != nil || got.Title != "Boom" {
+			t.Errorf("Expected the archived event for %s, got %v (err: %v)", eventID, got, err)
+		}
+	}
+
+	var ids []string
+	for issue, err := range a.IterIssues(ctx, "my-org", nil, &client.Paginator{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if strings.Join(ids, ",") != "2,1" {
+		t.Errorf("Expected issues most recently seen first, got %v", ids)
+	}
+
+	var notFound *archive.NotFoundError
+	if _, err := a.GetIssueEvent(ctx, "my-org", "2", "latest", nil); !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError for a missing event, got %v", err)
+	}
+	if _, err := a.GetIssue(ctx, "other-org", "1"); !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError for a missing organization, got %v", err)
+	}
+	if _, err := a.GetIssue(ctx, "my-org", "../1"); err == nil {
+		t.Error("Expected an error for an issue ID escaping the archive")
+	}
+}
+
+func TestArchivePullAndOffline(t *testing.T) {
+	binary := buildSentire(t)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	var periods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 5 && parts[4] == "issues":
+			periods = append(periods, r.URL.Query().Get("statsPeriod"))
+			w.Write([]byte(`[{"id": "1", "shortId": "PROJ-1", "title": "First"}, {"id": "2", "shortId": "PROJ-2", "title": "Second"}]`))
+		case len(parts) == 8 && parts[6] == "events":
+			fmt.Fprintf(w, `{"eventID": "%s0000000000000000000000000000000", "title": "%s of %s"}`, parts[5], parts[7], parts[5])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	stdout, stderr, exitCode := runSentire(t, binary, "archive", "pull", "my-org", "--period", "7d", "--url", server.URL)
+	server.Close()
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stdout, "Archived 2 issues and 4 events") {
+		t.Errorf("Unexpected pull summary: %q", stdout)
+	}
+	if len(periods) != 1 || periods[0] != "7d" {
+		t.Errorf("Expected the issues to be listed with statsPeriod=7d, got %v", periods)
+	}
+
+	// The server is gone, so these can only be answered from the archive
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"events", "get-issue", "my-org", "2"}, `"title": "Second"`},
+		{[]string{"events", "get-issue-event", "my-org", "1", "latest"}, `"title": "latest of 1"`},
+		{[]string{"events", "list-issues", "my-org", "--with-event"}, `"title": "recommended of 2"`},
+		{[]string{"inspect", "https://my-org.sentry.io/issues/1/"}, `"title": "recommended of 1"`},
+	}
+	for _, tt := range tests {
+		args := append(tt.args, "--offline")
+		stdout, stderr, exitCode := runSentire(t, binary, args...)
+		if exitCode != 0 {
+			t.Errorf("%v: expected exit code 0, got %d\nstderr: %s", tt.args, exitCode, stderr)
+			continue
+		}
+		if !strings.Contains(stdout, tt.expected) {
+			t.Errorf("%v: expected output to contain %s, got:\n%s", tt.args, tt.expected, stdout)
+		}
+	}
+
+	_, stderr, exitCode = runSentire(t, binary, "events", "get-issue", "my-org", "3", "--offline")
+	if exitCode != 3 || !strings.Contains(stderr, `"code":"not_archived"`) {
+		t.Errorf("Expected exit code 3 and not_archived, got %d: %s", exitCode, stderr)
+	}
+
+	_, _, exitCode = runSentire(t, binary, "projects", "list", "--offline")
+	if exitCode != 4 {
+		t.Errorf("Expected exit code 4 for a command without offline support, got %d", exitCode)
+	}
+
+	// Search flags cannot be applied to the archive
+	for _, flag := range []string{"--query=is:resolved", "--limit=1"} {
+		name, _, _ := strings.Cut(flag, "=")
+		_, stderr, exitCode = runSentire(t, binary, "events", "list-issues", "my-org", flag, "--offline")
+		if exitCode != 4 || !strings.Contains(stderr, name+" is not supported with --offline") {
+			t.Errorf("Expected exit code 4 for %s offline, got %d: %s", name, exitCode, stderr)
+		}
+	}
+}
+
+func TestArchivePullMissingEvent(t *testing.T) {
+	binary := buildSentire(t)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 5 && parts[4] == "issues":
+			w.Write([]byte(`[{"id": "1", "shortId": "PROJ-1"}, {"id": "2", "shortId": "PROJ-2"}]`))
+		case len(parts) == 8 && parts[6] == "events" && !(parts[5] == "1" && parts[7] == "latest"):
+			fmt.Fprintf(w, `{"eventID": "%s0000000000000000000000000000000", "title": "%s of %s"}`, parts[5], parts[7], parts[5])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "archive", "pull", "my-org", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stdout, "Archived 2 issues and 3 events") || !strings.Contains(stdout, "1 issue archived without all of their events") {
+		t.Errorf("Unexpected pull summary: %q", stdout)
+	}
+	if !strings.Contains(stderr, "issue 1 was archived without some of its events") {
+		t.Errorf("Expected a warning for issue 1, got %q", stderr)
+	}
+
+	stdout, stderr, exitCode = runSentire(t, binary, "events", "get-issue-event", "my-org", "1", "recommended", "--offline")
+	if exitCode != 0 || !strings.Contains(stdout, "recommended of 1") {
+		t.Errorf("Expected the recommended event of issue 1 to be archived, got %d: %s%s", exitCode, stdout, stderr)
+	}
+}
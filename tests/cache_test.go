@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sentire/internal/client"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newETagHandler serves a fixed body with an ETag, answering matching
+// If-None-Match requests with 304. It counts requests and revalidations.
+func newETagHandler(requests, revalidations *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(revalidations, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id": "1"}`))
+	}
+}
+
+func readBody(t *testing.T, c *client.Client, endpoint string) string {
+	t.Helper()
+	resp, err := c.Get(context.Background(), endpoint, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestCacheServesFreshEntries(t *testing.T) {
+	var requests, revalidations int32
+	c, server := setupTestClient(newETagHandler(&requests, &revalidations))
+	defer server.Close()
+	c.Cache = client.NewCache(t.TempDir())
+
+	endpoint := "/organizations/my-org/issues/1/"
+	for i := 0; i < 3; i++ {
+		if body := readBody(t, c, endpoint); body != `{"id": "1"}` {
+			t.Errorf("Unexpected body: %q", body)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+
+	// Another token must not see the cached response
+	c.Token = "other-token"
+	readBody(t, c, endpoint)
+	if requests != 2 {
+		t.Errorf("Expected a new request for another token, got %d requests", requests)
+	}
+}
+
+func TestCacheRefreshRevalidates(t *testing.T) {
+	var requests, revalidations int32
+	c, server := setupTestClient(newETagHandler(&requests, &revalidations))
+	defer server.Close()
+	c.Cache = client.NewCache(t.TempDir())
+
+	endpoint := "/organizations/my-org/issues/1/"
+	readBody(t, c, endpoint)
+
+	c.Cache.Refresh = true
+	if body := readBody(t, c, endpoint); body != `{"id": "1"}` {
+		t.Errorf("Expected the cached body after a 304, got %q", body)
+	}
+	if requests != 2 || revalidations != 1 {
+		t.Errorf("Expected 2 requests with 1 revalidation, got %d and %d", requests, revalidations)
+	}
+}
+
+func TestCacheStatsAndClear(t *testing.T) {
+	var requests, revalidations int32
+	c, server := setupTestClient(newETagHandler(&requests, &revalidations))
+	defer server.Close()
+	cache := client.NewCache(filepath.Join(t.TempDir(), "sentire"))
+	c.Cache = cache
+
+	// A missing cache directory is simply empty
+	stats, err := cache.Stats()
+	if err != nil || stats.Entries != 0 {
+		t.Fatalf("Expected an empty cache, got %+v (err: %v)", stats, err)
+	}
+
+	readBody(t, c, "/organizations/my-org/issues/1/")
+	readBody(t, c, "/organizations/my-org/issues/2/")
+
+	stats, err = cache.Stats()
+	if err != nil || stats.Entries != 2 || stats.Fresh != 2 || stats.Size == 0 {
+		t.Errorf("Expected 2 fresh entries, got %+v (err: %v)", stats, err)
+	}
+
+	removed, err := cache.Clear()
+	if err != nil || removed != 2 {
+		t.Errorf("Expected 2 entries removed, got %d (err: %v)", removed, err)
+	}
+	if stats, _ := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("Expected an empty cache after clear, got %d entries", stats.Entries)
+	}
+}
+
+func TestCacheCommand(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, `{"sentry_api_token": "test-token", "cache": true}`)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var requests, revalidations int32
+	server := httptest.NewServer(newETagHandler(&requests, &revalidations))
+	defer server.Close()
+
+	getIssue := func(extra ...string) {
+		args := append([]string{"events", "get-issue", "my-org", "1", "--url", server.URL}, extra...)
+		cmd := exec.Command(binary, args...)
+		cmd.Env = os.Environ()
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("get-issue failed: %v\n%s", err, out)
+		}
+	}
+
+	getIssue()
+	getIssue()
+	if requests != 1 {
+		t.Errorf("Expected the second get-issue to be served from the cache, got %d requests", requests)
+	}
+	getIssue("--no-cache")
+	getIssue("--refresh")
+	if requests != 3 || revalidations != 1 {
+		t.Errorf("Expected --no-cache and --refresh to reach the API, got %d requests and %d revalidations", requests, revalidations)
+	}
+
+	stdout, stderr, exitCode := runSentire(t, binary, "cache", "stats")
+	if exitCode != 0 || !strings.Contains(stdout, "Entries:   1 (1 fresh)") {
+		t.Errorf("Unexpected cache stats output: %q (stderr: %s)", stdout, stderr)
+	}
+
+	stdout, _, _ = runSentire(t, binary, "cache", "clear")
+	if !strings.Contains(stdout, "Removed 1 cached responses") {
+		t.Errorf("Unexpected cache clear output: %q", stdout)
+	}
+}
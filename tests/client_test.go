@@ -1,11 +1,13 @@
 package tests
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"sentire/internal/client"
+	"sentire/internal/config"
 	"strings"
 	"testing"
 	"time"
@@ -40,6 +42,55 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestAPIBaseURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: client.BaseURL},
+		{input: "https://sentry.example.com", want: "https://sentry.example.com/api/0"},
+		{input: "https://sentry.example.com/", want: "https://sentry.example.com/api/0"},
+		{input: "https://de.sentry.io", want: "https://de.sentry.io/api/0"},
+		{input: "http://localhost:9000/sentry", want: "http://localhost:9000/sentry/api/0"},
+		{input: "https://sentry.example.com/api/0/", want: "https://sentry.example.com/api/0"},
+		{input: "sentry.example.com", wantErr: true},
+		{input: "ftp://sentry.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := client.APIBaseURL(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %s", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("APIBaseURL(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientWithConfigSelfHosted(t *testing.T) {
+	c, err := client.NewClientWithConfig(&config.Config{
+		SentryAPIToken: "test-token",
+		SentryURL:      "https://sentry.example.com",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if c.BaseURL != "https://sentry.example.com/api/0" {
+		t.Errorf("Expected self-hosted base URL, got %s", c.BaseURL)
+	}
+}
+
 func TestClientDo(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -149,7 +200,7 @@ func TestClientGet(t *testing.T) {
 	params := url.Values{}
 	params.Set("test", "value")
 
-	resp, err := c.Get("/test", params)
+	resp, err := c.Get(context.Background(), "/test", params)
 	if err != nil {
 		t.Fatalf("GET request failed: %v", err)
 	}
@@ -177,7 +228,7 @@ func TestClientError(t *testing.T) {
 
 	c.BaseURL = server.URL
 
-	_, err = c.Get("/test", nil)
+	_, err = c.Get(context.Background(), "/test", nil)
 	if err == nil {
 		t.Error("Expected error for 400 response")
 	}
@@ -205,7 +256,7 @@ func TestDecodeJSON(t *testing.T) {
 
 	c.BaseURL = server.URL
 
-	resp, err := c.Get("/test", nil)
+	resp, err := c.Get(context.Background(), "/test", nil)
 	if err != nil {
 		t.Fatalf("GET request failed: %v", err)
 	}
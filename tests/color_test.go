@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func createColorCommand(format, color string) *cobra.Command {
+	cmd := createTestCommand(format)
+	cmd.Flags().String("color", color, "Test color flag")
+	return cmd
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColorModes(t *testing.T) {
+	issues := []models.Issue{
+		{ShortID: "API-1", Title: "KeyError", Level: "error", Status: "unresolved", Count: "42"},
+		{ShortID: "API-2", Title: "Slow query", Level: "warning", Status: "resolved", Count: "3"},
+	}
+
+	for _, format := range []string{"text", "table"} {
+		t.Run(format, func(t *testing.T) {
+			var always, never, auto bytes.Buffer
+			for buf, mode := range map[*bytes.Buffer]string{&always: "always", &never: "never", &auto: "auto"} {
+				f, err := formatter.NewFormatter(createColorCommand(format, mode), buf)
+				if err != nil {
+					t.Fatalf("Failed to create formatter: %v", err)
+				}
+				if err := f.FormatIssues(issues); err != nil {
+					t.Fatalf("Failed to format issues: %v", err)
+				}
+			}
+
+			for _, expected := range []string{"\x1b[31merror\x1b[0m", "\x1b[33mwarning\x1b[0m", "\x1b[32mresolved\x1b[0m"} {
+				if !strings.Contains(always.String(), expected) {
+					t.Errorf("Expected %q in colored output, got:\n%s", expected, always.String())
+				}
+			}
+			if ansiEscape.MatchString(never.String()) {
+				t.Errorf("Expected no colors with --color never, got:\n%s", never.String())
+			}
+			// A buffer is not a terminal
+			if ansiEscape.MatchString(auto.String()) {
+				t.Errorf("Expected no colors when not writing to a terminal, got:\n%s", auto.String())
+			}
+			// Colors must not change the layout
+			if ansiEscape.ReplaceAllString(always.String(), "") != never.String() {
+				t.Errorf("Expected colored output to match uncolored output once colors are removed:\n%s\n%s", always.String(), never.String())
+			}
+		})
+	}
+
+	if _, err := formatter.NewFormatter(createColorCommand("text", "sometimes"), &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for an invalid color mode")
+	}
+}
+
+func TestColorNoColorEnv(t *testing.T) {
+	terminal, master := openTerminal(t)
+
+	// Only a non-empty NO_COLOR turns colors off
+	for value, colored := range map[string]bool{"1": false, "": true} {
+		t.Setenv("NO_COLOR", value)
+		cmd := createTemplateCommand(`{{.Level | color "red"}}`, "", "")
+		cmd.Flags().String("color", "auto", "Test color flag")
+
+		f, err := formatter.NewFormatter(cmd, terminal)
+		if err != nil {
+			t.Fatalf("Failed to create formatter: %v", err)
+		}
+		if err := f.FormatIssue(&models.Issue{Level: "error"}); err != nil {
+			t.Fatalf("Failed to format issue: %v", err)
+		}
+
+		output, err := bufio.NewReader(master).ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read the terminal output: %v", err)
+		}
+		if ansiEscape.MatchString(output) != colored {
+			t.Errorf("NO_COLOR=%q: expected colored=%v, got %q", value, colored, output)
+		}
+	}
+}
+
+func TestColorTextEvent(t *testing.T) {
+	lineNo := 42
+	inApp := true
+	event := &models.Event{
+		EventID: "abc123",
+		Exception: &models.Exception{Values: []models.ExceptionValue{{
+			Type:  "KeyError",
+			Value: "'user'",
+			Stacktrace: &models.Stacktrace{Frames: []models.StackFrame{
+				{Filename: "lib/server.py", Function: "serve"},
+				{Filename: "app/views.py", Function: "get_user", LineNo: &lineNo, InApp: &inApp},
+			}},
+		}}},
+		Breadcrumbs: &models.Breadcrumbs{Values: []models.Breadcrumb{
+			{Timestamp: time.Date(2026, 5, 1, 11, 59, 58, 0, time.UTC), Category: "db", Message: "connection lost", Level: "error"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	f, _ := formatter.NewFormatter(createColorCommand("text", "always"), &buf)
+	if err := f.FormatEvent(event); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"\x1b[1mat get_user (app/views.py:42)\x1b[0m",
+		"\x1b[90mat serve (lib/server.py)\x1b[0m",
+		"11:59:58 [\x1b[31merror\x1b[0m] db: connection lost",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Index(output, "get_user") > strings.Index(output, "serve") {
+		t.Error("Expected the most recent frame first")
+	}
+}
+
+func TestColorTemplateHelper(t *testing.T) {
+	cmd := createTemplateCommand(`{{.ShortID | color "red"}}`, "", "")
+	cmd.Flags().String("color", "always", "Test color flag")
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(cmd, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatIssue(&models.Issue{ShortID: "API-1"}); err != nil {
+		t.Fatalf("Failed to format issue: %v", err)
+	}
+	if buf.String() != "\x1b[31mAPI-1\x1b[0m\n" {
+		t.Errorf("Expected a red short ID, got %q", buf.String())
+	}
+}
+
+func TestInvalidColorFlag(t *testing.T) {
+	binary := buildSentire(t)
+
+	_, stderr, exitCode := runSentire(t, binary, "projects", "list", "--color", "sometimes")
+	if exitCode != 4 {
+		t.Errorf("Expected exit code 4, got %d\nstderr: %s", exitCode, stderr)
+	}
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sentire/internal/config"
+	"strings"
+	"testing"
+)
+
+func TestConfigSetGetUnset(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	cfg := &config.Config{}
+	if err := cfg.Set(config.KeySentryURL, "https://sentry.example.com", ""); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cfg.Set(config.KeyDefaultOrg, "platform", "onprem"); err != nil {
+		t.Fatalf("Set in profile failed: %v", err)
+	}
+	if err := cfg.Set(config.KeyMaxRetries, "5", ""); err != nil {
+		t.Fatalf("Set max_retries failed: %v", err)
+	}
+
+	if value, ok, _ := cfg.Get(config.KeySentryURL, ""); !ok || value != "https://sentry.example.com" {
+		t.Errorf("Expected sentry_url to be set, got %q (set=%v)", value, ok)
+	}
+	if value, ok, _ := cfg.Get(config.KeyDefaultOrg, "onprem"); !ok || value != "platform" {
+		t.Errorf("Expected default_org in profile, got %q (set=%v)", value, ok)
+	}
+	if value, _, _ := cfg.Get(config.KeyMaxRetries, ""); value != "5" {
+		t.Errorf("Expected max_retries 5, got %q", value)
+	}
+
+	if err := cfg.Unset(config.KeyDefaultOrg, "onprem"); err != nil {
+		t.Fatalf("Unset failed: %v", err)
+	}
+	if _, ok := cfg.Profiles["onprem"]; ok {
+		t.Error("Expected empty profile to be removed")
+	}
+}
+
+func TestConfigKeyErrors(t *testing.T) {
+	cfg := &config.Config{}
+
+	if err := cfg.Set("unknown_key", "x", ""); err == nil {
+		t.Error("Expected error for unknown key")
+	}
+	if err := cfg.Set(config.KeyMaxRetries, "3", "onprem"); err == nil {
+		t.Error("Expected error for top-level-only key in a profile")
+	}
+	if err := cfg.Set(config.KeyMaxRetries, "-1", ""); err == nil {
+		t.Error("Expected error for negative max_retries")
+	}
+}
+
+func TestSaveConfigPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	if err := config.SaveConfig(&config.Config{SentryAPIToken: "secret"}); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(tempDir, ".config", "sentire", "config.json"))
+	if err != nil {
+		t.Fatalf("Config file was not created: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("Expected config file mode 0600, got %o", mode)
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	binary := buildSentire(t)
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	if _, stderr, exitCode := runSentire(t, binary, "config", "set", "sentry_url", "https://sentry.example.com"); exitCode != 0 {
+		t.Fatalf("config set failed with exit code %d: %s", exitCode, stderr)
+	}
+	if _, stderr, exitCode := runSentire(t, binary, "config", "set", "sentry_api_token", "sntrys_secret_token_1234", "--profile", "onprem"); exitCode != 0 {
+		t.Fatalf("config set --profile failed with exit code %d: %s", exitCode, stderr)
+	}
+
+	stdout, _, exitCode := runSentire(t, binary, "config", "get", "sentry_url")
+	if exitCode != 0 || strings.TrimSpace(stdout) != "https://sentry.example.com" {
+		t.Errorf("config get = %q (exit %d), want https://sentry.example.com", stdout, exitCode)
+	}
+
+	stdout, _, _ = runSentire(t, binary, "config", "list")
+	if !strings.Contains(stdout, "sentry_url=https://sentry.example.com") {
+		t.Errorf("Expected sentry_url in config list, got %q", stdout)
+	}
+	if !strings.Contains(stdout, "profiles.onprem.sentry_api_token=********1234") {
+		t.Errorf("Expected masked profile token in config list, got %q", stdout)
+	}
+	if strings.Contains(stdout, "sntrys_secret") {
+		t.Errorf("Expected token to be masked, got %q", stdout)
+	}
+
+	stdout, _, _ = runSentire(t, binary, "config", "path")
+	expectedPath := filepath.Join(tempDir, ".config", "sentire", "config.json")
+	if strings.TrimSpace(stdout) != expectedPath {
+		t.Errorf("config path = %q, want %q", stdout, expectedPath)
+	}
+
+	if _, stderr, exitCode := runSentire(t, binary, "config", "unset", "sentry_url"); exitCode != 0 {
+		t.Fatalf("config unset failed with exit code %d: %s", exitCode, stderr)
+	}
+	if _, _, exitCode := runSentire(t, binary, "config", "get", "sentry_url"); exitCode == 0 {
+		t.Error("Expected config get of an unset key to fail")
+	}
+
+	_, stderr, exitCode := runSentire(t, binary, "config", "set", "sentry_url", "not-a-url")
+	if exitCode != 4 || !strings.Contains(stderr, "invalid_input") {
+		t.Errorf("Expected invalid_input for bad URL, got exit %d: %s", exitCode, stderr)
+	}
+
+	_, stderr, exitCode = runSentire(t, binary, "config", "set", "no_such_key", "x")
+	if exitCode != 4 || !strings.Contains(stderr, "invalid_input") {
+		t.Errorf("Expected invalid_input for unknown key, got exit %d: %s", exitCode, stderr)
+	}
+}
+
+func TestAuthLogin(t *testing.T) {
+	binary := buildSentire(t)
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/0/organizations/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail": "Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`[{"id": "1", "slug": "my-org", "name": "My Org"}]`))
+	}))
+	defer server.Close()
+
+	_, stderr, exitCode := runSentire(t, binary, "auth", "login", "--token", "bad-token", "--url", server.URL)
+	if exitCode != 2 {
+		t.Errorf("Expected exit code 2 for rejected token, got %d: %s", exitCode, stderr)
+	}
+
+	_, stderr, exitCode = runSentire(t, binary, "auth", "login", "--token", "good-token", "--url", server.URL, "--profile", "onprem")
+	if exitCode != 0 {
+		t.Fatalf("Expected login to succeed, got exit %d: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, "my-org") {
+		t.Errorf("Expected accessible organizations in output, got %q", stderr)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, ".config", "sentire", "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	var saved config.Config
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Invalid config file: %v", err)
+	}
+	profile := saved.Profiles["onprem"]
+	if profile == nil || profile.SentryAPIToken != "good-token" || profile.SentryURL != server.URL {
+		t.Errorf("Expected token and URL saved in profile onprem, got %+v", profile)
+	}
+}
@@ -264,6 +264,52 @@ func TestSaveConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfigSentryURL(t *testing.T) {
+	os.Unsetenv("SENTRY_URL")
+
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "sentire")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+
+	configData := `{"sentry_api_token": "test-file-token", "sentry_url": "https://sentry.example.com"}`
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(configData), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+
+	// Token from the environment, URL from the config file
+	os.Setenv("SENTRY_API_TOKEN", "test-env-token")
+	defer os.Unsetenv("SENTRY_API_TOKEN")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "test-env-token" {
+		t.Errorf("Expected env token, got %s", cfg.SentryAPIToken)
+	}
+	if cfg.SentryURL != "https://sentry.example.com" {
+		t.Errorf("Expected URL from config file, got %s", cfg.SentryURL)
+	}
+
+	// SENTRY_URL overrides the config file
+	os.Setenv("SENTRY_URL", "https://de.sentry.io")
+	defer os.Unsetenv("SENTRY_URL")
+
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryURL != "https://de.sentry.io" {
+		t.Errorf("Expected URL from SENTRY_URL, got %s", cfg.SentryURL)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsString(str, substr string) bool {
 	return len(str) >= len(substr) &&
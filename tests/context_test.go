@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetCanceledContext(t *testing.T) {
+	c, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Get(ctx, "/test", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRateLimitWaitHonorsContext(t *testing.T) {
+	c, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	// The budget is spent and only resets in an hour
+	c.RateLimit.Limit = 10
+	c.RateLimit.Remaining = 0
+	c.RateLimit.Reset = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the wait to end with the context, took %s", elapsed)
+	}
+}
+
+func TestRetryDelayHonorsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	c.Retry.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the retry delay to end with the context, took %s", elapsed)
+	}
+}
+
+// newStallingIssuesServer serves one page of issues linking to a second page
+// that never completes. started is closed once the second page is requested.
+func newStallingIssuesServer(started chan struct{}) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?cursor=page2>; rel="next"; results="true"; cursor="page2"`, server.URL, r.URL.Path))
+			w.Write([]byte(`[{"id": "1", "title": "First"}, {"id": "2", "title": "Second"}]`))
+			return
+		}
+		close(started)
+		<-r.Context().Done()
+	}))
+	return server
+}
+
+func TestTimeoutFlushesPartialNDJSON(t *testing.T) {
+	binary := buildSentire(t)
+	server := newStallingIssuesServer(make(chan struct{}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org",
+		"--all", "--format", "ndjson", "--timeout", "500ms", "--url", server.URL)
+
+	if exitCode != 5 {
+		t.Errorf("Expected exit code 5, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, `"code":"timeout"`) {
+		t.Errorf("Expected timeout error code, got %q", stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"First"`) {
+		t.Errorf("Expected the first page as 2 ndjson lines, got %q", stdout)
+	}
+}
+
+func TestInvalidTimeout(t *testing.T) {
+	binary := buildSentire(t)
+
+	_, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--timeout", "-1s")
+	if exitCode != 4 {
+		t.Errorf("Expected exit code 4, got %d\nstderr: %s", exitCode, stderr)
+	}
+}
+
+func TestInterruptFlushesPartialNDJSON(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals are not supported on Windows")
+	}
+
+	binary := buildSentire(t)
+	started := make(chan struct{})
+	server := newStallingIssuesServer(started)
+	defer server.Close()
+
+	cmd := exec.Command(binary, "events", "list-issues", "my-org", "--all", "--format", "ndjson", "--url", server.URL)
+	cmd.Env = append(os.Environ(), "SENTRY_API_TOKEN=test-token")
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start sentire: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("Timed out waiting for the second page request")
+	}
+	cmd.Process.Signal(os.Interrupt)
+
+	err := cmd.Wait()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 130 {
+		t.Errorf("Expected exit code 130, got %v\nstderr: %s", err, stderr.String())
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 {
+		t.Errorf("Expected the first page as 2 ndjson lines, got %q", stdout.String())
+	}
+}
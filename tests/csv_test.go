@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVIssues(t *testing.T) {
+	issues := []models.Issue{
+		{ID: "1", ShortID: "API-1", Title: "KeyError: 'user'", Level: "error", Status: "unresolved", Count: "42", UserCount: 7,
+			LastSeen: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), Project: models.IssueProject{Slug: "api"}},
+		{ID: "2", ShortID: "API-2", Title: "Timeout, retrying", Level: "warning", Status: "resolved", Count: "3", UserCount: 1,
+			Project: models.IssueProject{Slug: "api"}, AssignedTo: &models.IssueUser{Name: "Sam"}},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"csv", "id,shortId,title,level,status,priority,count,userCount,firstSeen,lastSeen,project,culprit,assignedTo,permalink\n" +
+			"1,API-1,KeyError: 'user',error,unresolved,,42,7,,2026-05-01T12:00:00Z,api,,,\n" +
+			"2,API-2,\"Timeout, retrying\",warning,resolved,,3,1,,,api,,Sam,\n"},
+		{"tsv", "id\tshortId\ttitle\tlevel\tstatus\tpriority\tcount\tuserCount\tfirstSeen\tlastSeen\tproject\tculprit\tassignedTo\tpermalink\n" +
+			"1\tAPI-1\tKeyError: 'user'\terror\tunresolved\t\t42\t7\t\t2026-05-01T12:00:00Z\tapi\t\t\t\n" +
+			"2\tAPI-2\tTimeout, retrying\twarning\tresolved\t\t3\t1\t\t\tapi\t\tSam\t\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			f, err := formatter.NewFormatter(createTestCommand(tt.format), &buf)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+			if err := f.FormatIssues(issues); err != nil {
+				t.Fatalf("Failed to format issues: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, buf.String())
+			}
+
+			// Streaming writes the same rows
+			var streamed bytes.Buffer
+			f, _ = formatter.NewFormatter(createTestCommand(tt.format), &streamed)
+			f.Begin()
+			for _, issue := range issues {
+				f.Record(issue)
+			}
+			f.End()
+			if streamed.String() != tt.expected {
+				t.Errorf("Expected streamed output to match, got:\n%s", streamed.String())
+			}
+		})
+	}
+}
+
+func TestCSVOrgStats(t *testing.T) {
+	stats := &models.OrganizationStats{
+		Start: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC),
+		Projects: []models.ProjectStatsDetail{{
+			ID:   "1",
+			Slug: "api",
+			Stats: []models.CategoryStats{{
+				Category: "error",
+				Outcomes: models.StatsOutcomes{Accepted: 900, Filtered: 50, RateLimited: 50},
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTestCommand("csv"), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatOrgStats(stats); err != nil {
+		t.Fatalf("Failed to format stats: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "start,end,project,category,outcome,quantity" {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+	if len(lines) != 8 {
+		t.Fatalf("Expected a header and one row per outcome, got %d lines:\n%s", len(lines), buf.String())
+	}
+	for _, expected := range []string{
+		"2026-05-01T00:00:00Z,2026-05-08T00:00:00Z,api,error,accepted,900",
+		"2026-05-01T00:00:00Z,2026-05-08T00:00:00Z,api,error,rate_limited,50",
+	} {
+		if !strings.Contains(buf.String(), expected+"\n") {
+			t.Errorf("Expected a row %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestCSVFields(t *testing.T) {
+	events := []models.Event{
+		{EventID: "a1", Tags: []models.EventTag{{Key: "browser", Value: "Chrome"}, {Key: "os", Value: "Linux"}}},
+		{EventID: "b2"},
+	}
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTestCommandWithFields("csv", "eventID:id,tags[*].value:tags"), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatEvents(events); err != nil {
+		t.Fatalf("Failed to format events: %v", err)
+	}
+
+	expected := "id,tags\na1,\"Chrome, Linux\"\nb2,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestCSVRowsFollowFirstHeader(t *testing.T) {
+	records := []interface{}{
+		map[string]interface{}{"b": 1, "a": "x"},
+		map[string]interface{}{"c": true, "b": 2},
+	}
+
+	var buf bytes.Buffer
+	f, _ := formatter.NewFormatter(createTestCommand("csv"), &buf)
+	if err := f.FormatGeneric(records); err != nil {
+		t.Fatalf("Failed to format records: %v", err)
+	}
+
+	expected := "a,b\nx,1\n,2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestCSVEmptyList(t *testing.T) {
+	binary := buildSentire(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	for _, extra := range []string{"--max-pages=1", "--all"} {
+		stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--format", "csv", extra, "--url", server.URL)
+		if exitCode != 0 {
+			t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+		}
+		if !strings.HasPrefix(stdout, "id,shortId,title,") || strings.Count(stdout, "\n") != 1 {
+			t.Errorf("%s: expected only the issue header, got %q", extra, stdout)
+		}
+	}
+}
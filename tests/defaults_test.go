@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/config"
+	"strings"
+	"testing"
+)
+
+const defaultsConfig = `{
+  "default_org": "file-org",
+  "default_project": "file-project",
+  "profiles": {
+    "work": {
+      "default_org": "work-org"
+    }
+  }
+}`
+
+func TestResolveConfigWithoutToken(t *testing.T) {
+	writeTestConfig(t, defaultsConfig)
+
+	cfg, err := config.ResolveConfig(config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.DefaultOrg != "file-org" || cfg.DefaultProject != "file-project" {
+		t.Errorf("Expected defaults from file, got org=%s project=%s", cfg.DefaultOrg, cfg.DefaultProject)
+	}
+
+	if _, err := config.LoadConfig(); err == nil {
+		t.Error("Expected LoadConfig to require a token")
+	}
+}
+
+func TestDefaultOrgAndProjectPrecedence(t *testing.T) {
+	writeTestConfig(t, defaultsConfig)
+	t.Setenv("SENTRY_ORG", "env-org")
+	t.Setenv("SENTRY_PROJECT", "env-project")
+
+	cfg, err := config.ResolveConfig(config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.DefaultOrg != "env-org" || cfg.DefaultProject != "env-project" {
+		t.Errorf("Expected environment to override file, got org=%s project=%s", cfg.DefaultOrg, cfg.DefaultProject)
+	}
+
+	// An explicitly selected profile wins over the environment, and settings
+	// it leaves out keep their environment values
+	cfg, err = config.ResolveConfig(config.LoadOptions{Profile: "work"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.DefaultOrg != "work-org" || cfg.DefaultProject != "env-project" {
+		t.Errorf("Expected org from profile and project from environment, got org=%s project=%s", cfg.DefaultOrg, cfg.DefaultProject)
+	}
+}
+
+func TestCommandsUseDefaultOrgAndProject(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, `{"default_org": "my-org", "default_project": "my-project"}`)
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"events", "list-issues"}, "/api/0/organizations/my-org/issues/"},
+		{[]string{"events", "list-project"}, "/api/0/projects/my-org/my-project/events/"},
+		{[]string{"events", "list-project", "other-project"}, "/api/0/projects/my-org/other-project/events/"},
+		{[]string{"events", "list-issue", "123456"}, "/api/0/organizations/my-org/issues/123456/events/"},
+		{[]string{"org", "list-projects"}, "/api/0/organizations/my-org/projects/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			paths = nil
+			args := append(tt.args, "--url", server.URL)
+			if _, stderr, exitCode := runSentire(t, binary, args...); exitCode != 0 {
+				t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+			}
+			if len(paths) != 1 || paths[0] != tt.expected {
+				t.Errorf("Expected request to %s, got %v", tt.expected, paths)
+			}
+		})
+	}
+}
+
+func TestMissingOrgArgumentWithoutDefault(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, `{}`)
+
+	_, stderr, exitCode := runSentire(t, binary, "events", "list-issues")
+	if exitCode != 4 {
+		t.Errorf("Expected exit code 4, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, "invalid_input") || !strings.Contains(stderr, "SENTRY_ORG") {
+		t.Errorf("Expected invalid_input error mentioning SENTRY_ORG, got %q", stderr)
+	}
+}
+
+func TestDescribeReportsDefaultedArgs(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, `{}`)
+	t.Setenv("SENTRY_ORG", "env-org")
+
+	stdout, _, exitCode := runSentire(t, binary, "describe", "events", "get-issue")
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d", exitCode)
+	}
+
+	var result struct {
+		Args []struct {
+			Name     string `json:"name"`
+			Required bool   `json:"required"`
+			Default  string `json:"default"`
+		} `json:"args"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\nOutput: %s", err, stdout)
+	}
+	if len(result.Args) != 2 {
+		t.Fatalf("Expected 2 args, got %d", len(result.Args))
+	}
+	if result.Args[0].Required || result.Args[0].Default != "env-org" {
+		t.Errorf("Expected optional organization defaulting to env-org, got %+v", result.Args[0])
+	}
+	if !result.Args[1].Required {
+		t.Errorf("Expected issue-id to stay required, got %+v", result.Args[1])
+	}
+}
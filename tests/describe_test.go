@@ -22,11 +22,24 @@ func TestDescribeAllCommands(t *testing.T) {
 			Description  string   `json:"description"`
 			OutputFields []string `json:"output_fields"`
 		} `json:"commands"`
+		GlobalFlags []struct {
+			Name string `json:"name"`
+		} `json:"global_flags"`
 	}
 	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
 		t.Fatalf("Invalid JSON output: %v\nOutput: %s", err, stdout)
 	}
 
+	globalFlags := make(map[string]bool)
+	for _, flag := range result.GlobalFlags {
+		globalFlags[flag.Name] = true
+	}
+	for _, name := range []string{"format", "fields", "jq"} {
+		if !globalFlags[name] {
+			t.Errorf("Expected global flag %q in describe output", name)
+		}
+	}
+
 	if len(result.Commands) == 0 {
 		t.Fatal("Expected at least one command in describe output")
 	}
@@ -40,6 +53,7 @@ func TestDescribeAllCommands(t *testing.T) {
 		"events list-issues",
 		"events get-issue",
 		"events get-event",
+		"events threads",
 		"inspect",
 		"projects list",
 		"projects get",
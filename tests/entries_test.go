@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+)
+
+func loadEventFixture(t *testing.T, name string) (*models.Event, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var event models.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("Failed to decode %s: %v", name, err)
+	}
+	return &event, data
+}
+
+func TestEntriesPythonEvent(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_python.json")
+
+	if _, ok := event.Entries[0].Data.(*models.Exception); !ok {
+		t.Fatalf("Expected exception data to be *models.Exception, got %T", event.Entries[0].Data)
+	}
+
+	exceptions := event.Exceptions()
+	if len(exceptions) != 2 || exceptions[0].Type != "KeyError" || exceptions[1].Type != "ValueError" {
+		t.Fatalf("Expected KeyError then ValueError, got %+v", exceptions)
+	}
+	if handled := exceptions[1].Mechanism.Handled; handled == nil || *handled {
+		t.Error("Expected the ValueError to be unhandled")
+	}
+
+	frame := exceptions[1].Stacktrace.Frames[1]
+	lines, current := frame.SourceContext()
+	if len(lines) != 5 || current != 4 || lines[current].Code != "        raise ValueError('invalid user id') from e" {
+		t.Errorf("Expected 5 context lines with the frame's line last, got %+v (current %d)", lines, current)
+	}
+	if lines[0] != (models.ContextLine{LineNo: 40, Code: "def get_user(request):"}) {
+		t.Errorf("Unexpected first context line %+v", lines[0])
+	}
+
+	breadcrumbs := event.BreadcrumbValues()
+	if len(breadcrumbs) != 2 || breadcrumbs[1].Category != "httplib" || breadcrumbs[1].Level != "warning" {
+		t.Errorf("Unexpected breadcrumbs %+v", breadcrumbs)
+	}
+	if breadcrumbs[1].Data["status_code"] != float64(404) {
+		t.Errorf("Expected breadcrumb data to be decoded, got %v", breadcrumbs[1].Data)
+	}
+
+	request := event.HTTPRequest()
+	if request == nil || request.Method != "GET" || request.URL != "https://api.example.com/users/1" {
+		t.Fatalf("Unexpected request %+v", request)
+	}
+	if request.Headers["User-Agent"] != "curl/8.5.0" || request.Cookies["sessionid"] != "[Filtered]" {
+		t.Errorf("Expected header and cookie pairs to be decoded, got %v and %v", request.Headers, request.Cookies)
+	}
+
+	if entry := event.LogEntry(); entry == nil || entry.Formatted != "Failed to load user 1" || entry.Message != "Failed to load user %s" {
+		t.Errorf("Unexpected message entry %+v", entry)
+	}
+
+	template := event.Template()
+	if template == nil || template.Filename != "users/detail.html" || len(template.Context) != 3 {
+		t.Fatalf("Unexpected template entry %+v", template)
+	}
+	if template.Context[1].Code != "{{ user.profile.bio|markdown }}" {
+		t.Errorf("Unexpected template context %+v", template.Context)
+	}
+
+	if event.Threads() != nil || event.Spans() != nil || event.DebugMeta() != nil || event.CSPReport() != nil {
+		t.Error("Expected no data for entry types the event does not have")
+	}
+}
+
+func TestEntriesNativeEvent(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_native.json")
+
+	threads := event.Threads()
+	if len(threads) != 2 {
+		t.Fatalf("Expected 2 threads, got %d", len(threads))
+	}
+	crashed := threads[1]
+	if crashed.ID != float64(2) || crashed.Name != "worker" || !crashed.Crashed || !crashed.Current {
+		t.Errorf("Unexpected crashed thread %+v", crashed)
+	}
+	if len(crashed.Stacktrace.Frames) != 2 || crashed.Stacktrace.Registers["rip"] != "0x100002b44" {
+		t.Errorf("Unexpected crashed thread stack trace %+v", crashed.Stacktrace)
+	}
+	if threads[0].State != "WAITING" || threads[0].Crashed {
+		t.Errorf("Unexpected main thread %+v", threads[0])
+	}
+
+	meta := event.DebugMeta()
+	if meta == nil || len(meta.Images) != 1 {
+		t.Fatalf("Unexpected debug meta %+v", meta)
+	}
+	image := meta.Images[0]
+	if image.Type != "macho" || image.DebugID != "8fe1bd6c-4a7e-3b0f-9c48-6b9e7a0e2d11" || image.ImageSize != 65536 {
+		t.Errorf("Unexpected debug image %+v", image)
+	}
+
+	exceptions := event.Exceptions()
+	if len(exceptions) != 1 || exceptions[0].Stacktrace != nil || *exceptions[0].ThreadID != 2 {
+		t.Errorf("Expected an exception pointing at thread 2, got %+v", exceptions)
+	}
+}
+
+func TestEntriesTransactionAndSecurityEvents(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_transaction.json")
+	spans := event.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Op != "db" || span.SpanID != "a1b2c3d4e5f60718" || span.Timestamp-span.StartTimestamp < 0.2 {
+		t.Errorf("Unexpected span %+v", span)
+	}
+
+	event, _ = loadEventFixture(t, "event_security.json")
+	csp := event.CSPReport()
+	if csp == nil || csp.BlockedURI != "https://evil.example.com/x.js" || csp.EffectiveDirective != "script-src" || *csp.LineNumber != 12 {
+		t.Errorf("Unexpected CSP report %+v", csp)
+	}
+	ct := event.ExpectCTReport()
+	if ct == nil || ct.Hostname != "www.example.com" || *ct.Port != 443 || len(ct.ServedCertificateChain) != 1 {
+		t.Errorf("Unexpected Expect-CT report %+v", ct)
+	}
+
+	// Unknown entry types keep their generic data
+	if data, ok := event.Entries[2].Data.(map[string]interface{}); !ok || data["unknown"] != true {
+		t.Errorf("Expected generic data for an unknown entry type, got %#v", event.Entries[2].Data)
+	}
+}
+
+func TestEntriesRoundTrip(t *testing.T) {
+	for _, name := range []string{"event_python.json", "event_native.json", "event_transaction.json", "event_security.json"} {
+		t.Run(name, func(t *testing.T) {
+			event, data := loadEventFixture(t, name)
+
+			var original struct {
+				Entries []interface{} `json:"entries"`
+			}
+			if err := json.Unmarshal(data, &original); err != nil {
+				t.Fatalf("Failed to decode fixture: %v", err)
+			}
+
+			encoded, err := json.Marshal(event)
+			if err != nil {
+				t.Fatalf("Failed to encode event: %v", err)
+			}
+			var roundTripped struct {
+				Entries []interface{} `json:"entries"`
+			}
+			if err := json.Unmarshal(encoded, &roundTripped); err != nil {
+				t.Fatalf("Failed to decode encoded event: %v", err)
+			}
+
+			if !reflect.DeepEqual(original.Entries, roundTripped.Entries) {
+				t.Errorf("Expected entries to be written as received")
+			}
+		})
+	}
+}
+
+func TestEntriesChangedData(t *testing.T) {
+	var entry models.Entry
+	if err := json.Unmarshal([]byte(`{"type":"request","data":{"method":"GET","url":"https://example.com","unknownField":1}}`), &entry); err != nil {
+		t.Fatalf("Failed to decode entry: %v", err)
+	}
+
+	// Unchanged data is written back as received, unknown fields included
+	encoded, _ := json.Marshal(entry)
+	if !strings.Contains(string(encoded), `"unknownField":1`) {
+		t.Errorf("Expected the entry as received, got %s", encoded)
+	}
+
+	request := entry.Data.(*models.Request)
+	request.Method = "POST"
+	encoded, _ = json.Marshal(entry)
+	if !strings.Contains(string(encoded), `"method":"POST"`) {
+		t.Errorf("Expected the changed method, got %s", encoded)
+	}
+
+	entry.Data = map[string]interface{}{"method": "PUT"}
+	encoded, _ = json.Marshal(entry)
+	if string(encoded) != `{"type":"request","data":{"method":"PUT"}}` {
+		t.Errorf("Expected the replaced data, got %s", encoded)
+	}
+}
+
+func TestEntriesBuiltInCode(t *testing.T) {
+	// Entries built in code with generic data are converted by the accessors
+	event := &models.Event{Entries: []models.Entry{
+		{Type: "request", Data: map[string]interface{}{"method": "POST", "url": "https://example.com"}},
+	}}
+	if request := event.HTTPRequest(); request == nil || request.Method != "POST" {
+		t.Errorf("Unexpected request %+v", request)
+	}
+
+	encoded, _ := json.Marshal(event.Entries[0])
+	if string(encoded) != `{"type":"request","data":{"method":"POST","url":"https://example.com"}}` {
+		t.Errorf("Unexpected encoding %s", encoded)
+	}
+}
+
+func TestTraceFromAPIFixture(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_python.json")
+
+	var buf bytes.Buffer
+	f, _ := formatter.NewFormatter(createTraceCommand(false, false), &buf)
+	if err := f.FormatEvent(event); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+
+	expected := `Traceback (most recent call last):
+  ... 1 system frame hidden ...
+  File "app/views.py", line 44, in get_user
+    40 | def get_user(request):
+    41 |     try:
+    42 |         user = load_user(request.session)
+    43 |     except KeyError as e:
+  > 44 |         raise ValueError('invalid user id') from e
+ValueError: invalid user id (unhandled)
+`
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected the trace to end with:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
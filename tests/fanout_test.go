@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sentire/internal/client"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// intSeq yields 1..n, then err if it is not nil
+func intSeq(n int, err error) func(yield func(int, error) bool) {
+	return func(yield func(int, error) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(0, err)
+		}
+	}
+}
+
+// concurrencyProbe records the highest number of calls running at once
+type concurrencyProbe struct {
+	mu      sync.Mutex
+	running int
+	max     int
+}
+
+func (p *concurrencyProbe) call(ctx context.Context, item int) (int, error) {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.max {
+		p.max = p.running
+	}
+	p.mu.Unlock()
+
+	// Later items finish first, so ordering is not accidental
+	time.Sleep(time.Duration(10-item%10) * time.Millisecond)
+
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+	return item * 10, nil
+}
+
+func TestFanOutPreservesOrder(t *testing.T) {
+	c := &client.Client{Concurrency: 3, RateLimit: &client.RateLimiter{}}
+	probe := &concurrencyProbe{}
+
+	var results []int
+	for value, err := range client.FanOut(context.Background(), c, intSeq(10, nil), probe.call) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		results = append(results, value)
+	}
+
+	expected := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %v, got %v", expected, results)
+	}
+	if probe.max > 3 {
+		t.Errorf("Expected at most 3 concurrent calls, got %d", probe.max)
+	}
+	if probe.max < 2 {
+		t.Errorf("Expected calls to run concurrently, got at most %d at once", probe.max)
+	}
+}
+
+func TestFanOutRespectsConcurrentLimit(t *testing.T) {
+	c := &client.Client{Concurrency: 8, RateLimit: &client.RateLimiter{ConcurrentLimit: 2}}
+	probe := &concurrencyProbe{}
+
+	for _, err := range client.FanOut(context.Background(), c, intSeq(10, nil), probe.call) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if probe.max > 2 {
+		t.Errorf("Expected the concurrent limit of 2 to be respected, got %d", probe.max)
+	}
+}
+
+func TestFanOutErrors(t *testing.T) {
+	c := &client.Client{Concurrency: 4, RateLimit: &client.RateLimiter{}}
+	fnErr := errors.New("fetch failed")
+	inErr := errors.New("page failed")
+
+	tests := []struct {
+		name          string
+		in            func(yield func(int, error) bool)
+		expectedItems []int
+		expectedErr   error
+	}{
+		{"function error", intSeq(6, nil), []int{1, 2}, fnErr},
+		{"input error", intSeq(2, inErr), []int{1, 2}, inErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := func(ctx context.Context, item int) (int, error) {
+				if item == 3 {
+					return 0, fnErr
+				}
+				return item, nil
+			}
+
+			var items []int
+			var errs []error
+			for value, err := range client.FanOut(context.Background(), c, tt.in, fn) {
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				items = append(items, value)
+			}
+
+			if !reflect.DeepEqual(items, tt.expectedItems) {
+				t.Errorf("Expected items %v before the error, got %v", tt.expectedItems, items)
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], tt.expectedErr) {
+				t.Errorf("Expected a single %v error, got %v", tt.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestListIssuesWithEvent(t *testing.T) {
+	binary := buildSentire(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events/recommended/") {
+			issueID := strings.Split(r.URL.Path, "/")[6]
+			fmt.Fprintf(w, `{"id": "event-%s", "eventID": "event-%s"}`, issueID, issueID)
+			return
+		}
+		w.Write([]byte(`[{"id": "1", "shortId": "P-1"}, {"id": "2", "shortId": "P-2"}, {"id": "3", "shortId": "P-3"}]`))
+	}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org",
+		"--with-event", "--concurrency", "2", "--format", "ndjson", "--fields", "id,recommendedEvent", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 issues, got %q", stdout)
+	}
+	for i, line := range lines {
+		expected := fmt.Sprintf(`"eventID":"event-%d"`, i+1)
+		if !strings.Contains(line, expected) {
+			t.Errorf("Expected issue %d to contain %s, got %s", i+1, expected, line)
+		}
+	}
+
+	_, _, exitCode = runSentire(t, binary, "events", "list-issues", "my-org", "--concurrency", "0", "--url", server.URL)
+	if exitCode != 4 {
+		t.Errorf("Expected exit code 4 for --concurrency 0, got %d", exitCode)
+	}
+}
+
+func TestListIssuesWithEventMissing(t *testing.T) {
+	binary := buildSentire(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events/recommended/") {
+			issueID := strings.Split(r.URL.Path, "/")[6]
+			if issueID == "2" {
+				http.Error(w, `{"detail": "The requested resource does not exist"}`, http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "event-%s", "eventID": "event-%s"}`, issueID, issueID)
+			return
+		}
+		w.Write([]byte(`[{"id": "1", "shortId": "P-1"}, {"id": "2", "shortId": "P-2"}, {"id": "3", "shortId": "P-3"}]`))
+	}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org",
+		"--with-event", "--format", "ndjson", "--fields", "id,recommendedEvent", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected all 3 issues, got %q", stdout)
+	}
+	if strings.Contains(lines[1], "eventID") || !strings.Contains(lines[2], `"eventID":"event-3"`) {
+		t.Errorf("Expected only issue 2 to be missing its event, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "Warning: failed to fetch the recommended event of issue P-2") {
+		t.Errorf("Expected a warning for issue P-2, got %q", stderr)
+	}
+}
+
+func TestListIssuesWithEventAfterWhere(t *testing.T) {
+	binary := buildSentire(t)
+
+	var fetched sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events/recommended/") {
+			issueID := strings.Split(r.URL.Path, "/")[6]
+			fetched.Store(issueID, true)
+			fmt.Fprintf(w, `{"id": "event-%s", "eventID": "event-%s"}`, issueID, issueID)
+			return
+		}
+		w.Write([]byte(`[{"id": "1", "level": "error"}, {"id": "2", "level": "warning"}, {"id": "3", "level": "error"}]`))
+	}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org",
+		"--with-event", "--where", `level == "error"`, "--format", "ndjson", "--fields", "id", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout), "\n"); len(lines) != 2 {
+		t.Errorf("Expected the 2 error issues, got %q", stdout)
+	}
+	if _, ok := fetched.Load("2"); ok {
+		t.Error("Expected no event request for the issue left out by --where")
+	}
+}
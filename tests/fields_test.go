@@ -3,8 +3,10 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"sentire/internal/cli/formatter"
 	"sentire/pkg/models"
+	"strings"
 	"testing"
 	"time"
 
@@ -125,3 +127,76 @@ func TestNoFieldsFilteringReturnsAll(t *testing.T) {
 		t.Errorf("Expected many fields without filter, got %d", len(result))
 	}
 }
+
+func TestFieldsNestedPaths(t *testing.T) {
+	event := &models.Event{
+		EventID: "evt1",
+		Tags:    []models.EventTag{{Key: "browser", Value: "Chrome"}, {Key: "os", Value: "Linux"}},
+		Contexts: &models.Contexts{
+			OS:      &models.OSContext{Name: "Linux"},
+			Runtime: &models.RuntimeContext{Name: "CPython"},
+		},
+		Exception: &models.Exception{Values: []models.ExceptionValue{{Type: "KeyError"}, {Type: "ValueError"}}},
+	}
+
+	var buf bytes.Buffer
+	cmd := createTestCommandWithFields("json", "eventID:id,tags[*].key,tags[1].value:os,contexts.*.name,exception.values.type,missing.path")
+	f, err := formatter.NewFormatter(cmd, &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatEvent(event); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+
+	expected := `{"id":"evt1","tags[*].key":["browser","os"],"os":"Linux","contexts.*.name":["Linux","CPython"],"exception.values.type":["KeyError","ValueError"]}`
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, buf.Bytes()); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+	if compact.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, compact.String())
+	}
+}
+
+func TestFieldsInvalidSpec(t *testing.T) {
+	for _, fields := range []string{"tags[x].key", "tags[0", "id:", "a..b", "id,title:id"} {
+		cmd := createTestCommandWithFields("json", fields)
+		var fieldsErr *formatter.FieldsError
+		if _, err := formatter.NewFormatter(cmd, &bytes.Buffer{}); !errors.As(err, &fieldsErr) {
+			t.Errorf("%s: expected a FieldsError, got %v", fields, err)
+		}
+	}
+}
+
+func TestFieldsHonoredByAllFormats(t *testing.T) {
+	issues := []models.Issue{
+		{ID: "1", Title: "First", Project: models.IssueProject{Slug: "api"}},
+		{ID: "2", Title: "Second", Project: models.IssueProject{Slug: "web"}},
+	}
+
+	for _, format := range []string{"table", "text", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := createTestCommandWithFields(format, "id,project.slug:project")
+			f, err := formatter.NewFormatter(cmd, &buf)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+			if err := f.FormatIssues(issues); err != nil {
+				t.Fatalf("Failed to format issues: %v", err)
+			}
+
+			// Table headers are upper-cased
+			output := buf.String()
+			for _, expected := range []string{"project", "api", "web"} {
+				if !strings.Contains(strings.ToLower(output), expected) {
+					t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+				}
+			}
+			if strings.Contains(output, "First") {
+				t.Errorf("Expected unselected fields to be left out, got:\n%s", output)
+			}
+		})
+	}
+}
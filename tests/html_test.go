@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bytes"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLEvent(t *testing.T) {
+	lineNo := 42
+	inApp := true
+	event := &models.Event{
+		EventID:     "abc123",
+		Title:       "KeyError: <user>",
+		DateCreated: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		Tags:        []models.EventTag{{Key: "environment", Value: "production"}},
+		Entries: []models.Entry{
+			{Type: "exception", Data: map[string]interface{}{
+				"values": []interface{}{map[string]interface{}{
+					"type":  "KeyError",
+					"value": "'user'",
+					"stacktrace": map[string]interface{}{
+						"frames": []interface{}{
+							map[string]interface{}{"filename": "lib/server.py", "function": "serve"},
+							map[string]interface{}{
+								"filename":    "app/views.py",
+								"function":    "get_user",
+								"lineNo":      lineNo,
+								"inApp":       inApp,
+								"preContext":  []interface{}{"def get_user(request):"},
+								"contextLine": "    return request.session['user']",
+								"postContext": []interface{}{""},
+							},
+						},
+					},
+				}},
+			}},
+			{Type: "breadcrumbs", Data: map[string]interface{}{
+				"values": []interface{}{map[string]interface{}{
+					"timestamp": "2026-05-01T11:59:58Z",
+					"category":  "http",
+					"message":   "GET /users/1",
+				}},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTestCommand("html"), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatEvent(event); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"<title>KeyError: &lt;user&gt;</title>",
+		`<details class="frame" open>`,
+		"<code>get_user</code> in <code>app/views.py:42</code>",
+		`<details class="frame system">`,
+		`<td class="lineno">41</td><td>def get_user(request):</td>`,
+		`<tr class="current"><td class="lineno">42</td>`,
+		"GET /users/1",
+		"<td>environment</td><td>production</td>",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	// The most recent frame comes first
+	if strings.Index(output, "get_user") > strings.Index(output, "serve") {
+		t.Error("Expected the in-app frame before the system frame")
+	}
+
+	// The page must not load anything
+	for _, external := range []string{"<link", "<script", "src="} {
+		if strings.Contains(output, external) {
+			t.Errorf("Expected no external assets, found %q", external)
+		}
+	}
+}
+
+func TestHTMLIssues(t *testing.T) {
+	issues := []models.Issue{
+		{ShortID: "API-1", Title: "KeyError", Level: "error", Permalink: "https://sentry.io/organizations/acme/issues/1/"},
+		{ShortID: "API-2", Title: "<script>alert(1)</script>", Level: "warning"},
+	}
+
+	var batch bytes.Buffer
+	f, _ := formatter.NewFormatter(createTestCommand("html"), &batch)
+	if err := f.FormatIssues(issues); err != nil {
+		t.Fatalf("Failed to format issues: %v", err)
+	}
+	output := batch.String()
+
+	if !strings.Contains(output, `<a href="https://sentry.io/organizations/acme/issues/1/">API-1</a>`) {
+		t.Errorf("Expected a link to the issue permalink, got:\n%s", output)
+	}
+	if strings.Contains(output, "<script>") || !strings.Contains(output, "&lt;script&gt;") {
+		t.Errorf("Expected titles to be escaped, got:\n%s", output)
+	}
+
+	var streamed bytes.Buffer
+	f, _ = formatter.NewFormatter(createTestCommand("html"), &streamed)
+	f.Begin()
+	for _, issue := range issues {
+		f.Record(issue)
+	}
+	f.End()
+	if streamed.String() != output {
+		t.Errorf("Expected streamed output to match:\n%s\nGot:\n%s", output, streamed.String())
+	}
+
+	var selected bytes.Buffer
+	f, _ = formatter.NewFormatter(createTestCommandWithFields("html", "shortId,title"), &selected)
+	if err := f.FormatIssues(issues); err != nil {
+		t.Fatalf("Failed to format issues: %v", err)
+	}
+	if !strings.Contains(selected.String(), "<th>shortId</th><th>title</th>") {
+		t.Errorf("Expected a table of the selected fields, got:\n%s", selected.String())
+	}
+}
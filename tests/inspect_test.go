@@ -1,11 +1,14 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sentire/internal/api"
 	"sentire/pkg/models"
+	"strings"
 	"testing"
 	"time"
 )
@@ -139,7 +142,7 @@ func TestInspectCommandIntegration(t *testing.T) {
 	// Test that the inspect logic would work
 	// (We can't easily test the CLI command directly in this test framework,
 	// but we can test the underlying API call it makes)
-	event, err := eventsAPI.GetIssueEvent("laterpay", "6796439331", "recommended", nil)
+	event, err := eventsAPI.GetIssueEvent(context.Background(), "laterpay", "6796439331", "recommended", nil)
 	if err != nil {
 		t.Fatalf("GetIssueEvent failed: %v", err)
 	}
@@ -171,3 +174,65 @@ func TestInspectCommandIntegration(t *testing.T) {
 		t.Errorf("Expected user email 'test@example.com', got %s", event.User.Email)
 	}
 }
+
+func TestInspectSelfHostedURL(t *testing.T) {
+	binary := buildSentire(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/api/0/organizations/my-org/issues/42/events/recommended/"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.Event{ID: "self-hosted-event", GroupID: "42"})
+	}))
+	defer server.Close()
+
+	// The token is never sent to a host that is not configured
+	_, stderr, exitCode := runSentire(t, binary, "inspect", server.URL+"/organizations/my-org/issues/42/", "--fields", "id")
+	if exitCode != 4 || !strings.Contains(stderr, "not the configured Sentry instance") {
+		t.Fatalf("Expected an invalid input error for an unconfigured host, got exit code %d\nstderr: %s", exitCode, stderr)
+	}
+
+	t.Setenv("SENTRY_URL", server.URL)
+
+	// Nor is the token of a self-hosted instance sent to a sentry.io region
+	_, stderr, exitCode = runSentire(t, binary, "inspect", "https://my-org.de.sentry.io/issues/42/")
+	if exitCode != 4 || !strings.Contains(stderr, "different Sentry instance") {
+		t.Fatalf("Expected an invalid input error for a sentry.io region, got exit code %d\nstderr: %s", exitCode, stderr)
+	}
+
+	stdout, stderr, exitCode := runSentire(t, binary, "inspect", server.URL+"/organizations/my-org/issues/42/", "--fields", "id")
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stdout, "self-hosted-event") {
+		t.Errorf("Expected event from self-hosted server, got %s", stdout)
+	}
+}
+
+func TestInspectInvalidURLs(t *testing.T) {
+	binary := buildSentire(t)
+
+	urls := []string{
+		"https://example.com/issues/123",
+		"https://laterpay.notsentry.io/issues/123/",
+		"https://laterpay.xx.sentry.io/issues/123/",
+		"https://laterpay.sentry.io/not-issues/123/",
+		"https://sentry.example.com/organizations/My_Org/issues/123/",
+		"not-a-url",
+	}
+
+	for _, u := range urls {
+		t.Run(u, func(t *testing.T) {
+			_, stderr, exitCode := runSentire(t, binary, "inspect", u)
+			if exitCode != 4 {
+				t.Errorf("exit code = %d, want 4\nstderr: %s", exitCode, stderr)
+			}
+			if !strings.Contains(stderr, "invalid_input") {
+				t.Errorf("stderr = %q, want it to contain invalid_input", stderr)
+			}
+		})
+	}
+}
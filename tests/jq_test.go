@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJQ(t *testing.T) {
+	binary := buildSentire(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"id": "1", "title": "First", "level": "error", "project": {"slug": "api"}}, {"id": "2", "title": "Second", "level": "warning", "project": {"slug": "web"}}]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"raw strings in text format", []string{"--jq", `.[] | .title`, "--format", "text"}, "First\nSecond\n"},
+		{"compact values in ndjson format", []string{"--jq", `.[] | {id, level}`, "--format", "ndjson"}, "{\"id\":\"1\",\"level\":\"error\"}\n{\"id\":\"2\",\"level\":\"warning\"}\n"},
+		{"indented json", []string{"--jq", `map(.id)`}, "[\n  \"1\",\n  \"2\"\n]\n"},
+		{"applied after --fields", []string{"--fields", "id,project.slug:project", "--jq", `.[1]`, "--format", "ndjson"}, "{\"id\":\"2\",\"project\":\"web\"}\n"},
+		{"streaming listings are collected", []string{"--all", "--jq", `length`}, "2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"events", "list-issues", "my-org", "--url", server.URL}, tt.args...)
+			stdout, stderr, exitCode := runSentire(t, binary, args...)
+			if exitCode != 0 {
+				t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+			}
+			if stdout != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, stdout)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"invalid program", []string{"--jq", `.[] |`}, `"code":"invalid_input"`},
+		{"runtime error", []string{"--jq", `.[] | .title | .[]`}, `"code":"invalid_input"`},
+		{"unsupported format", []string{"--jq", `.`, "--format", "table"}, "does not support the table format"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"events", "list-issues", "my-org", "--url", server.URL}, tt.args...)
+			_, stderr, exitCode := runSentire(t, binary, args...)
+			if exitCode != 4 || !strings.Contains(stderr, tt.expected) {
+				t.Errorf("Expected exit code 4 with %s, got %d: %s", tt.expected, exitCode, stderr)
+			}
+		})
+	}
+
+	before := requests
+	runSentire(t, binary, "events", "list-issues", "my-org", "--url", server.URL, "--jq", `.[`)
+	if requests != before {
+		t.Error("Expected an invalid --jq program to be rejected before any request")
+	}
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"os"
@@ -57,7 +58,7 @@ func TestListOrgProjects(t *testing.T) {
 		Cursor: "test-cursor",
 	}
 
-	projects, pagination, err := orgAPI.ListProjects("test-org", opts)
+	projects, pagination, err := orgAPI.ListProjects(context.Background(), "test-org", opts)
 	if err != nil {
 		t.Fatalf("ListProjects failed: %v", err)
 	}
@@ -162,7 +163,7 @@ func TestGetOrgStats(t *testing.T) {
 		Project:     []string{"1", "2"},
 	}
 
-	stats, err := orgAPI.GetStats("test-org", opts)
+	stats, err := orgAPI.GetStats(context.Background(), "test-org", opts)
 	if err != nil {
 		t.Fatalf("GetStats failed: %v", err)
 	}
@@ -195,14 +196,14 @@ func TestGetStatsWithoutField(t *testing.T) {
 	orgAPI := api.NewOrganizationsAPI(c)
 
 	// Test with nil options
-	_, err := orgAPI.GetStats("test-org", nil)
+	_, err := orgAPI.GetStats(context.Background(), "test-org", nil)
 	if err == nil {
 		t.Error("Expected error when opts is nil")
 	}
 
 	// Test with empty field
 	opts := &api.GetStatsOptions{}
-	_, err = orgAPI.GetStats("test-org", opts)
+	_, err = orgAPI.GetStats(context.Background(), "test-org", opts)
 	if err == nil {
 		t.Error("Expected error when field is empty")
 	}
@@ -211,3 +212,29 @@ func TestGetStatsWithoutField(t *testing.T) {
 		t.Errorf("Expected error message to contain 'field parameter is required', got: %v", err)
 	}
 }
+
+func TestDownloadStats(t *testing.T) {
+	csvData := "project,category,quantity\napi,error,1000\n"
+	c, server := setupTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("download") != "true" {
+			t.Errorf("Expected download=true, got %q", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("field") != "sum(quantity)" {
+			t.Errorf("Expected field=sum(quantity), got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csvData))
+	})
+	defer server.Close()
+	defer os.Unsetenv("SENTRY_API_TOKEN")
+
+	orgAPI := api.NewOrganizationsAPI(c)
+
+	data, err := orgAPI.DownloadStats(context.Background(), "test-org", &api.GetStatsOptions{Field: "sum(quantity)"})
+	if err != nil {
+		t.Fatalf("DownloadStats failed: %v", err)
+	}
+	if string(data) != csvData {
+		t.Errorf("Expected the CSV as sent by the server, got %q", data)
+	}
+}
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sentire/internal/client"
+	"strings"
+	"testing"
+)
+
+// fakePages serves pages of three items at cursors "", "c1" and "c2"
+func fakePages(fetched *[]string) client.PageFunc[int] {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {[]int{1, 2, 3}, "c1"},
+		"c1": {[]int{4, 5, 6}, "c2"},
+		"c2": {[]int{7, 8, 9}, ""},
+	}
+	return func(ctx context.Context, cursor string) ([]int, *client.PaginationInfo, error) {
+		*fetched = append(*fetched, cursor)
+		page, ok := pages[cursor]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown cursor %q", cursor)
+		}
+		return page.items, &client.PaginationInfo{NextCursor: page.next, HasNext: page.next != ""}, nil
+	}
+}
+
+func collectInts(t *testing.T, pager *client.Paginator, fetch client.PageFunc[int]) []int {
+	t.Helper()
+	var items []int
+	for item, err := range client.Paginate(context.Background(), pager, fetch) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name           string
+		pager          client.Paginator
+		expectedItems  []int
+		expectedCursor string
+		expectedPages  int
+		partial        bool
+	}{
+		{"all pages", client.Paginator{}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, "", 3, false},
+		{"max pages", client.Paginator{MaxPages: 2}, []int{1, 2, 3, 4, 5, 6}, "c2", 2, false},
+		{"max items mid page", client.Paginator{MaxItems: 4}, []int{1, 2, 3, 4}, "c2", 2, true},
+		{"max items at page end", client.Paginator{MaxItems: 3}, []int{1, 2, 3}, "c1", 1, false},
+		{"resume from cursor", client.Paginator{Cursor: "c1"}, []int{4, 5, 6, 7, 8, 9}, "", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			pager := tt.pager
+			items := collectInts(t, &pager, fakePages(&fetched))
+
+			if !reflect.DeepEqual(items, tt.expectedItems) {
+				t.Errorf("Expected items %v, got %v", tt.expectedItems, items)
+			}
+			if pager.NextCursor != tt.expectedCursor {
+				t.Errorf("Expected next cursor %q, got %q", tt.expectedCursor, pager.NextCursor)
+			}
+			if pager.Pages != tt.expectedPages || len(fetched) != tt.expectedPages {
+				t.Errorf("Expected %d pages, got %d (fetched %v)", tt.expectedPages, pager.Pages, fetched)
+			}
+			if pager.Partial != tt.partial {
+				t.Errorf("Expected partial %v, got %v", tt.partial, pager.Partial)
+			}
+		})
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	var fetched []string
+	pages := fakePages(&fetched)
+	fetchErr := errors.New("boom")
+	fetch := func(ctx context.Context, cursor string) ([]int, *client.PaginationInfo, error) {
+		if cursor == "c1" {
+			return nil, nil, fetchErr
+		}
+		return pages(ctx, cursor)
+	}
+
+	pager := &client.Paginator{}
+	var items []int
+	var errs []error
+	for item, err := range client.Paginate(context.Background(), pager, fetch) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if len(items) != 3 || len(errs) != 1 || !errors.Is(errs[0], fetchErr) {
+		t.Errorf("Expected 3 items then one error, got items=%v errs=%v", items, errs)
+	}
+	// Resuming retries the page that failed
+	if pager.NextCursor != "c1" {
+		t.Errorf("Expected next cursor c1, got %q", pager.NextCursor)
+	}
+}
+
+func TestPaginateBreak(t *testing.T) {
+	var fetched []string
+	pager := &client.Paginator{}
+	for item := range client.Paginate(context.Background(), pager, fakePages(&fetched)) {
+		if item == 2 {
+			break
+		}
+	}
+
+	if len(fetched) != 1 {
+		t.Errorf("Expected a single page fetch, got %v", fetched)
+	}
+	if !pager.Partial {
+		t.Error("Expected a break before the end of the page to be partial")
+	}
+}
+
+func TestListIssuesPaginationFlags(t *testing.T) {
+	binary := buildSentire(t)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		next := map[string]string{"": "c1", "c1": "c2", "c2": ""}[cursor]
+		if next != "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?cursor=%s>; rel="next"; results="true"; cursor="%s"`, server.URL, r.URL.Path, next, next))
+		}
+		fmt.Fprintf(w, `[{"id": "%s-a"}, {"id": "%s-b"}]`, cursor, cursor)
+	}))
+	defer server.Close()
+
+	ids := func(stdout string) []string {
+		var issues []struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &issues); err != nil {
+			t.Fatalf("Invalid JSON output: %v\nOutput: %s", err, stdout)
+		}
+		var result []string
+		for _, issue := range issues {
+			result = append(result, issue.ID)
+		}
+		return result
+	}
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--max-pages", "2", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if got := ids(stdout); !reflect.DeepEqual(got, []string{"-a", "-b", "c1-a", "c1-b"}) {
+		t.Errorf("Unexpected issues for --max-pages 2: %v", got)
+	}
+	if !strings.Contains(stderr, "--cursor c2") {
+		t.Errorf("Expected next cursor on stderr, got %q", stderr)
+	}
+
+	stdout, stderr, _ = runSentire(t, binary, "events", "list-issues", "my-org", "--cursor", "c2", "--all", "--url", server.URL)
+	if got := ids(stdout); !reflect.DeepEqual(got, []string{"c2-a", "c2-b"}) {
+		t.Errorf("Unexpected issues when resuming from c2: %v", got)
+	}
+	if strings.Contains(stderr, "--cursor") {
+		t.Errorf("Expected no next cursor after the last page, got %q", stderr)
+	}
+
+	stdout, stderr, _ = runSentire(t, binary, "events", "list-issues", "my-org", "--max-items", "3", "--url", server.URL)
+	if got := ids(stdout); !reflect.DeepEqual(got, []string{"-a", "-b", "c1-a"}) {
+		t.Errorf("Unexpected issues for --max-items 3: %v", got)
+	}
+	// Resuming from c2 would skip c1-b
+	if strings.Contains(stderr, "--cursor c2") || !strings.Contains(stderr, "cannot be resumed") {
+		t.Errorf("Expected no resume cursor after stopping mid-page, got %q", stderr)
+	}
+}
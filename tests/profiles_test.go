@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"sentire/internal/config"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `{
+  "sentry_api_token": "top-level-token",
+  "sentry_url": "https://sentry.io",
+  "default_org": "top-org",
+  "profiles": {
+    "saas": {
+      "sentry_api_token": "saas-token",
+      "default_org": "saas-org"
+    },
+    "onprem": {
+      "sentry_api_token": "onprem-token",
+      "sentry_url": "https://sentry.example.com",
+      "default_org": "onprem-org"
+    }
+  }
+}`
+
+// writeTestConfig points HOME at a temp dir containing the given config file
+// and clears the environment variables that influence config loading
+func writeTestConfig(t *testing.T, content string) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	configDir := filepath.Join(tempDir, ".config", "sentire")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	t.Setenv("HOME", tempDir)
+	t.Setenv("SENTRY_API_TOKEN", "")
+	t.Setenv("SENTRY_AUTH_TOKEN", "")
+	t.Setenv("SENTRY_URL", "")
+	t.Setenv("SENTIRE_PROFILE", "")
+	t.Setenv("SENTRY_ORG", "")
+	t.Setenv("SENTRY_PROJECT", "")
+}
+
+func TestLoadConfigWithoutProfileUsesTopLevel(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cfg.SentryAPIToken != "top-level-token" || cfg.DefaultOrg != "top-org" {
+		t.Errorf("Expected top-level values, got token=%s org=%s", cfg.SentryAPIToken, cfg.DefaultOrg)
+	}
+	if cfg.Profile != "" {
+		t.Errorf("Expected no active profile, got %s", cfg.Profile)
+	}
+}
+
+func TestLoadConfigProfileOption(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+
+	cfg, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: "onprem"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cfg.Profile != "onprem" {
+		t.Errorf("Expected active profile onprem, got %s", cfg.Profile)
+	}
+	if cfg.SentryAPIToken != "onprem-token" {
+		t.Errorf("Expected onprem token, got %s", cfg.SentryAPIToken)
+	}
+	if cfg.SentryURL != "https://sentry.example.com" {
+		t.Errorf("Expected onprem URL, got %s", cfg.SentryURL)
+	}
+	if cfg.DefaultOrg != "onprem-org" {
+		t.Errorf("Expected onprem org, got %s", cfg.DefaultOrg)
+	}
+}
+
+func TestLoadConfigProfileInheritsTopLevel(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+
+	cfg, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: "saas"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cfg.SentryURL != "https://sentry.io" {
+		t.Errorf("Expected URL inherited from top level, got %s", cfg.SentryURL)
+	}
+}
+
+func TestLoadConfigProfileFromEnvironment(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+	t.Setenv("SENTIRE_PROFILE", "saas")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "saas-token" {
+		t.Errorf("Expected saas token from SENTIRE_PROFILE, got %s", cfg.SentryAPIToken)
+	}
+
+	// The option overrides SENTIRE_PROFILE
+	cfg, err = config.LoadConfigWithOptions(config.LoadOptions{Profile: "onprem"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "onprem-token" {
+		t.Errorf("Expected profile option to override SENTIRE_PROFILE, got %s", cfg.SentryAPIToken)
+	}
+}
+
+func TestLoadConfigExplicitProfileOverridesEnvironment(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+	t.Setenv("SENTRY_API_TOKEN", "env-token")
+	t.Setenv("SENTRY_URL", "https://env.example.com")
+
+	cfg, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: "onprem"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "onprem-token" {
+		t.Errorf("Expected explicit profile token to win over SENTRY_API_TOKEN, got %s", cfg.SentryAPIToken)
+	}
+	if cfg.SentryURL != "https://sentry.example.com" {
+		t.Errorf("Expected explicit profile URL to win over SENTRY_URL, got %s", cfg.SentryURL)
+	}
+}
+
+func TestLoadConfigEnvironmentOverridesDefaultProfile(t *testing.T) {
+	writeTestConfig(t, strings.Replace(profilesConfig, `"default_org": "top-org",`, `"default_org": "top-org", "default_profile": "onprem",`, 1))
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.Profile != "onprem" || cfg.SentryAPIToken != "onprem-token" {
+		t.Errorf("Expected default_profile onprem to be used, got profile=%s token=%s", cfg.Profile, cfg.SentryAPIToken)
+	}
+
+	t.Setenv("SENTRY_API_TOKEN", "env-token")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "env-token" {
+		t.Errorf("Expected SENTRY_API_TOKEN to win over default_profile, got %s", cfg.SentryAPIToken)
+	}
+	if cfg.SentryURL != "https://sentry.example.com" {
+		t.Errorf("Expected URL from default_profile, got %s", cfg.SentryURL)
+	}
+}
+
+func TestLoadConfigUnknownProfile(t *testing.T) {
+	writeTestConfig(t, profilesConfig)
+
+	_, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: "missing"})
+	if err == nil {
+		t.Fatal("Expected error for unknown profile")
+	}
+	if _, ok := err.(*config.AuthError); !ok {
+		t.Errorf("Expected *config.AuthError, got %T", err)
+	}
+	if !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("Expected error to name the profile, got %q", err.Error())
+	}
+}
+
+func TestProfileFlagUnknownProfile(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, profilesConfig)
+
+	_, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--profile", "missing")
+	if exitCode != 2 {
+		t.Errorf("Expected exit code 2, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, "auth_missing") {
+		t.Errorf("Expected stderr to contain 'auth_missing', got %q", stderr)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package tests
+
+import (
+	"os"
+	"testing"
+)
+
+// openTerminal is only implemented on Linux
+func openTerminal(t *testing.T) (terminal, master *os.File) {
+	t.Helper()
+	t.Skip("pseudo-terminals are only opened on Linux")
+	return nil, nil
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/client"
+	"sentire/internal/config"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitWaitsForReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+
+	// Pretend a previous response exhausted the budget
+	c.RateLimit.Limit = 10
+	c.RateLimit.Remaining = 0
+	c.RateLimit.Reset = time.Now().Add(300 * time.Millisecond)
+
+	start := time.Now()
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("Expected request to wait for the rate limit reset, took %s", elapsed)
+	}
+}
+
+func TestRateLimitDoesNotWaitWithBudgetLeft(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+
+	c.RateLimit.Limit = 10
+	c.RateLimit.Remaining = 5
+	c.RateLimit.Reset = time.Now().Add(10 * time.Second)
+
+	start := time.Now()
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected request not to wait, took %s", elapsed)
+	}
+}
+
+func TestRateLimitRespectsConcurrentLimit(t *testing.T) {
+	var current, maxSeen int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("X-Sentry-Rate-Limit-ConcurrentLimit", "2")
+		w.Header().Set("X-Sentry-Rate-Limit-ConcurrentRemaining", strconv.Itoa(2-int(n)))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+
+	// The first response tells the client about the concurrent limit
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Get(context.Background(), "/test", nil)
+			if err != nil {
+				t.Errorf("Request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxSeen > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, server saw %d", maxSeen)
+	}
+}
+
+func TestRateLimitVerboseOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Sentry-Rate-Limit-Limit", "40")
+		w.Header().Set("X-Sentry-Rate-Limit-Remaining", "39")
+		w.Header().Set("X-Sentry-Rate-Limit-Reset", strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+
+	var buf bytes.Buffer
+	c.Verbose = &buf
+
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if !strings.Contains(buf.String(), "39/40 requests remaining") {
+		t.Errorf("Expected rate limit budget in verbose output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,231 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/client"
+	"sentire/internal/config"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient creates a client pointed at server with fast retries
+func newRetryTestClient(t *testing.T, server *httptest.Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+	c.Retry.BaseDelay = time.Millisecond
+	c.Retry.MaxDelay = 5 * time.Millisecond
+	return c
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryOnRateLimitWithRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+
+	resp, err := c.Get(context.Background(), "/test", nil)
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retry, got %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestNoRetryWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+
+	_, err := c.Get(context.Background(), "/test", nil)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected a rate limit error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	c.Retry.MaxRetries = 2
+
+	_, err := c.Get(context.Background(), "/test", nil)
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		t.Fatalf("Expected *client.APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", apiErr.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts (1 + 2 retries), got %d", calls)
+	}
+}
+
+func TestNoRetryForClientErrorsOrNonIdempotentRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+
+	if _, err := c.Get(context.Background(), "/test", nil); err == nil {
+		t.Error("Expected error for 404 response")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 404 not to be retried, got %d attempts", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/test", strings.NewReader(`{}`))
+	req.GetBody = nil
+	if _, err := c.Do(req); err == nil {
+		t.Error("Expected error for 503 response")
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	maxRetries := 0
+	c, err := client.NewClientWithConfig(&config.Config{SentryAPIToken: "test-token", MaxRetries: &maxRetries})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.BaseURL = server.URL
+
+	if _, err := c.Get(context.Background(), "/test", nil); err == nil {
+		t.Error("Expected error for 500 response")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt with max_retries=0, got %d", calls)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := client.RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+	t.Run("Retry-After seconds", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", "7")
+		if got := policy.Delay(0, resp); got != 7*time.Second {
+			t.Errorf("Expected 7s, got %s", got)
+		}
+	})
+
+	t.Run("Retry-After HTTP date", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+		resp.Header.Set("Retry-After", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+		got := policy.Delay(0, resp)
+		if got < 8*time.Second || got > 10*time.Second {
+			t.Errorf("Expected about 10s, got %s", got)
+		}
+	})
+
+	t.Run("Sentry rate limit reset", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		reset := time.Now().Add(5 * time.Second).Unix()
+		resp.Header.Set("X-Sentry-Rate-Limit-Reset", strconv.FormatInt(reset, 10))
+		got := policy.Delay(0, resp)
+		if got <= 3*time.Second || got > 5*time.Second {
+			t.Errorf("Expected about 5s, got %s", got)
+		}
+	})
+
+	t.Run("server hint capped by MaxDelay", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", "120")
+		if got := policy.Delay(0, resp); got != policy.MaxDelay {
+			t.Errorf("Expected %s, got %s", policy.MaxDelay, got)
+		}
+	})
+
+	policy.MaxDelay = time.Second
+	t.Run("exponential backoff with jitter", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+		for retries, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+			got := policy.Delay(retries, resp)
+			if got < max/2 || got > max {
+				t.Errorf("retry %d: expected delay in [%s, %s], got %s", retries, max/2, max, got)
+			}
+		}
+	})
+}
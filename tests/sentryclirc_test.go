@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sentire/internal/config"
+	"strings"
+	"testing"
+)
+
+const homeSentryCLIRC = `; sentry-cli configuration
+[auth]
+token = rc-token
+
+[defaults]
+url = https://sentry.example.com/
+org = rc-org
+project = rc-project
+`
+
+// writeSentryCLIRC writes a .sentryclirc file into dir
+func writeSentryCLIRC(t *testing.T, dir, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, ".sentryclirc")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write .sentryclirc: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFromSentryCLIRC(t *testing.T) {
+	writeTestConfig(t, `{}`)
+	rcPath := writeSentryCLIRC(t, os.Getenv("HOME"), homeSentryCLIRC)
+	t.Chdir(t.TempDir())
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cfg.SentryAPIToken != "rc-token" || cfg.SentryURL != "https://sentry.example.com" {
+		t.Errorf("Expected token and URL from .sentryclirc, got token=%s url=%s", cfg.SentryAPIToken, cfg.SentryURL)
+	}
+	if cfg.DefaultOrg != "rc-org" || cfg.DefaultProject != "rc-project" {
+		t.Errorf("Expected defaults from .sentryclirc, got org=%s project=%s", cfg.DefaultOrg, cfg.DefaultProject)
+	}
+	if cfg.Sources[config.KeySentryAPIToken] != rcPath {
+		t.Errorf("Expected token source %s, got %q", rcPath, cfg.Sources[config.KeySentryAPIToken])
+	}
+}
+
+func TestSentryCLIRCPrecedence(t *testing.T) {
+	writeTestConfig(t, `{"default_org": "file-org"}`)
+	writeSentryCLIRC(t, os.Getenv("HOME"), homeSentryCLIRC)
+
+	// A project-local file is found from a subdirectory and overrides the
+	// one in the home directory
+	projectDir := t.TempDir()
+	localPath := writeSentryCLIRC(t, projectDir, "[defaults]\nproject=local-project\n")
+	subDir := filepath.Join(projectDir, "src", "app")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	t.Chdir(subDir)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.DefaultProject != "local-project" || cfg.Sources[config.KeyDefaultProject] != localPath {
+		t.Errorf("Expected project from %s, got %s (source %q)", localPath, cfg.DefaultProject, cfg.Sources[config.KeyDefaultProject])
+	}
+	// The sentire config file wins over .sentryclirc
+	if cfg.DefaultOrg != "file-org" {
+		t.Errorf("Expected org from config file, got %s", cfg.DefaultOrg)
+	}
+
+	// SENTRY_AUTH_TOKEN overrides the files, SENTRY_API_TOKEN overrides both
+	t.Setenv("SENTRY_AUTH_TOKEN", "auth-env-token")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "auth-env-token" || cfg.Sources[config.KeySentryAPIToken] != "SENTRY_AUTH_TOKEN environment variable" {
+		t.Errorf("Expected token from SENTRY_AUTH_TOKEN, got %s (source %q)", cfg.SentryAPIToken, cfg.Sources[config.KeySentryAPIToken])
+	}
+
+	t.Setenv("SENTRY_API_TOKEN", "api-env-token")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "api-env-token" || cfg.Sources[config.KeySentryAPIToken] != "SENTRY_API_TOKEN environment variable" {
+		t.Errorf("Expected token from SENTRY_API_TOKEN, got %s (source %q)", cfg.SentryAPIToken, cfg.Sources[config.KeySentryAPIToken])
+	}
+}
+
+func TestLocalSentryCLIRCURLNeedsLocalToken(t *testing.T) {
+	writeTestConfig(t, `{}`)
+	homePath := writeSentryCLIRC(t, os.Getenv("HOME"), homeSentryCLIRC)
+
+	// A checkout cannot point the user's token at a host of its choosing
+	projectDir := t.TempDir()
+	localPath := writeSentryCLIRC(t, projectDir, "[defaults]\nurl=https://attacker.example\nproject=local-project\n")
+	t.Chdir(projectDir)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryURL != "https://sentry.example.com" || cfg.Sources[config.KeySentryURL] != homePath {
+		t.Errorf("Expected the URL from %s, got %s (source %q)", homePath, cfg.SentryURL, cfg.Sources[config.KeySentryURL])
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], localPath) {
+		t.Errorf("Expected a warning about %s, got %v", localPath, cfg.Warnings)
+	}
+	// Other local settings still apply
+	if cfg.DefaultProject != "local-project" {
+		t.Errorf("Expected project from %s, got %s", localPath, cfg.DefaultProject)
+	}
+
+	// With its own token, the local file's URL is used
+	writeSentryCLIRC(t, projectDir, "[auth]\ntoken=local-token\n[defaults]\nurl=https://sentry.local.example\n")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryURL != "https://sentry.local.example" || cfg.SentryAPIToken != "local-token" || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected URL and token from %s, got url=%s token=%s warnings=%v", localPath, cfg.SentryURL, cfg.SentryAPIToken, cfg.Warnings)
+	}
+}
+
+func TestAuthStatus(t *testing.T) {
+	binary := buildSentire(t)
+	writeTestConfig(t, `{}`)
+	rcPath := writeSentryCLIRC(t, os.Getenv("HOME"), homeSentryCLIRC)
+
+	stdout, stderr, exitCode := runSentire(t, binary, "auth", "status")
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	for _, expected := range []string{
+		"Token:        ********oken (from SENTRY_API_TOKEN environment variable)",
+		"Sentry URL:   https://sentry.example.com (from " + rcPath + ")",
+		"Organization: rc-org (from " + rcPath + ")",
+	} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, stdout)
+		}
+	}
+
+	stdout, _, _ = runSentire(t, binary, "auth", "status", "--url", "https://de.sentry.io")
+	if !strings.Contains(stdout, "Sentry URL:   https://de.sentry.io (from --url flag)") {
+		t.Errorf("Expected URL from --url flag, got:\n%s", stdout)
+	}
+}
+
+func TestAuthStatusWithoutToken(t *testing.T) {
+	binary := buildSentire(t)
+
+	cmd := exec.Command(binary, "auth", "status")
+	cmd.Dir = t.TempDir()
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + t.TempDir()}
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Expected exit code 0, got %v\nstderr: %s", err, stderr.String())
+	}
+	for _, expected := range []string{"Token:        (not set)", "Sentry URL:   https://sentry.io (default)"} {
+		if !strings.Contains(stdout.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, stdout.String())
+		}
+	}
+}
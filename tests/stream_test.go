@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"runtime"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var streamTestIssues = []models.Issue{
+	{ID: "1", ShortID: "PROJ-1", Title: "First issue", Level: "error", Status: "unresolved", Count: "10"},
+	{ID: "2", ShortID: "PROJ-2", Title: "Second issue", Level: "warning", Status: "resolved", Count: "3"},
+}
+
+// streamIssues writes issues through the Begin/Record/End path
+func streamIssues(t *testing.T, format string, issues []models.Issue) string {
+	t.Helper()
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTestCommand(format), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.Begin(); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	for _, issue := range issues {
+		if err := f.Record(issue); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	if err := f.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestStreamMatchesBatchOutput(t *testing.T) {
+	for _, format := range []string{"json", "ndjson", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			f, err := formatter.NewFormatter(createTestCommand(format), &buf)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+			if err := f.FormatIssues(streamTestIssues); err != nil {
+				t.Fatalf("FormatIssues failed: %v", err)
+			}
+
+			if streamed := streamIssues(t, format, streamTestIssues); streamed != buf.String() {
+				t.Errorf("Streamed output differs from batch output\nstreamed:\n%s\nbatch:\n%s", streamed, buf.String())
+			}
+		})
+	}
+}
+
+func TestStreamEmptyList(t *testing.T) {
+	var issues []models.Issue
+	if err := json.Unmarshal([]byte(streamIssues(t, "json", nil)), &issues); err != nil || len(issues) != 0 {
+		t.Errorf("Expected an empty JSON array, got %v (err: %v)", issues, err)
+	}
+	if output := streamIssues(t, "ndjson", nil); output != "" {
+		t.Errorf("Expected no ndjson output, got %q", output)
+	}
+	for _, format := range []string{"table", "text"} {
+		if output := streamIssues(t, format, nil); !strings.Contains(output, "No data found") {
+			t.Errorf("Expected 'No data found' for %s, got %q", format, output)
+		}
+	}
+}
+
+func TestStreamTableAndText(t *testing.T) {
+	table := streamIssues(t, "table", streamTestIssues)
+	for _, expected := range []string{"ID", "TITLE", "PROJ-1", "First issue", "PROJ-2", "Second issue"} {
+		if !strings.Contains(table, expected) {
+			t.Errorf("Expected table output to contain %q, got:\n%s", expected, table)
+		}
+	}
+
+	text := streamIssues(t, "text", streamTestIssues)
+	for _, expected := range []string{"1. Issue #PROJ-1", "2. Issue #PROJ-2", "Total: 2"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected text output to contain %q, got:\n%s", expected, text)
+		}
+	}
+
+	markdown := streamIssues(t, "markdown", streamTestIssues)
+	if !strings.Contains(markdown, "# Issues (2 total)") {
+		t.Errorf("Expected buffered markdown with a total, got:\n%s", markdown)
+	}
+}
+
+// lockedBuffer collects the output of a running command
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestListStreamsPagesAsTheyArrive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals are not supported on Windows")
+	}
+
+	binary := buildSentire(t)
+	started := make(chan struct{})
+	server := newStallingIssuesServer(started)
+	defer server.Close()
+
+	cmd := exec.Command(binary, "events", "list-issues", "my-org", "--all", "--url", server.URL)
+	cmd.Env = append(os.Environ(), "SENTRY_API_TOKEN=test-token")
+	var stdout lockedBuffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.Discard
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start sentire: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the second page request")
+	}
+
+	// The first page must be written while the second one is still pending
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(stdout.String(), `"Second"`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the first page before the listing finished, got %q", stdout.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cmd.Process.Signal(os.Interrupt)
+	cmd.Wait()
+
+	// Stopping early still closes the JSON array
+	var issues []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout.String()), &issues); err != nil || len(issues) != 2 {
+		t.Errorf("Expected a JSON array of the first page, got %q (err: %v)", stdout.String(), err)
+	}
+}
+
+func TestListFailingFirstPageWritesNothing(t *testing.T) {
+	binary := buildSentire(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail": "not found"}`))
+	}))
+	defer server.Close()
+
+	stdout, _, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--all", "--url", server.URL)
+	if exitCode == 0 {
+		t.Fatal("Expected the listing to fail")
+	}
+	if stdout != "" {
+		t.Errorf("Expected no output when the first page fails, got %q", stdout)
+	}
+}
+
+func TestListEmptyStreamIsTerminated(t *testing.T) {
+	binary := buildSentire(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	for format, expected := range map[string]string{"json": "[]\n", "yaml": "[]\n", "html": "</html>", "text": "No data found"} {
+		stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org", "--all", "--format", format, "--url", server.URL)
+		if exitCode != 0 {
+			t.Fatalf("%s: expected exit code 0, got %d\nstderr: %s", format, exitCode, stderr)
+		}
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("%s: expected the output to contain %q, got %q", format, expected, stdout)
+		}
+	}
+}
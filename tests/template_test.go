@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func createTemplateCommand(template, templateFile, fields string) *cobra.Command {
+	cmd := createTestCommandWithFields("template", fields)
+	cmd.Flags().String("template", template, "Test template flag")
+	cmd.Flags().String("template-file", templateFile, "Test template file flag")
+	return cmd
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	issues := []models.Issue{
+		{ShortID: "API-1", Title: "KeyError in checkout handler", Count: "42", Level: "error",
+			LastSeen: time.Now().Add(-3*time.Hour - time.Minute), Project: models.IssueProject{Slug: "api"}},
+		{ShortID: "API-2", Title: "Slow query", Count: "3", Level: "warning",
+			LastSeen: time.Now().Add(-2 * 24 * time.Hour), Project: models.IssueProject{Slug: "api"}},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		fields   string
+		expected string
+	}{
+		{"per item", `{{.ShortID}} {{.Title | truncate 10}} ({{.Count}})`, "",
+			"API-1 KeyErro... (42)\nAPI-2 Slow query (3)\n"},
+		{"helpers", `{{.ShortID | color "red"}} {{.LastSeen | timeago}} {{.Project | json}}`, "",
+			"API-1 3h ago {\"id\":\"\",\"name\":\"\",\"slug\":\"api\"}\nAPI-2 2d ago {\"id\":\"\",\"name\":\"\",\"slug\":\"api\"}\n"},
+		{"records left out", `{{if eq .Level "error"}}{{.ShortID}}{{end}}`, "",
+			"API-1\n"},
+		{"fields", `{{.id}}@{{.project}}`, "shortId:id,project.slug:project",
+			"API-1@api\nAPI-2@api\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f, err := formatter.NewFormatter(createTemplateCommand(tt.template, "", tt.fields), &buf)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+			if err := f.FormatIssues(issues); err != nil {
+				t.Fatalf("Failed to format issues: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestTemplateTruncate(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{`{{.Title | truncate 5}}`, "ét..."},
+		{`{{.Title | truncate 11}}`, "été à Paris"},
+		{`{{.Title | truncate 2}}`, "ét"},
+		{`{{.Title | truncate 0}}`, ""},
+		{`{{.Title | truncate -1}}`, ""},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		f, err := formatter.NewFormatter(createTemplateCommand(tt.template, "", ""), &buf)
+		if err != nil {
+			t.Fatalf("Failed to create formatter: %v", err)
+		}
+		if err := f.FormatIssue(&models.Issue{Title: "été à Paris"}); err != nil {
+			t.Fatalf("%s: failed to format issue: %v", tt.template, err)
+		}
+		if got := strings.TrimSuffix(buf.String(), "\n"); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.template, tt.expected, got)
+		}
+	}
+}
+
+func TestTemplateFileSingleObject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event.tmpl")
+	content := "Event {{.EventID}}\nFingerprint: {{.Fingerprint | join \", \"}}\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTemplateCommand("", path, ""), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	event := &models.Event{EventID: "abc", Fingerprint: []string{"{{ default }}", "checkout"}}
+	if err := f.FormatEvent(event); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+
+	expected := "Event abc\nFingerprint: {{ default }}, checkout\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	var templateErr *formatter.TemplateError
+
+	for _, cmd := range []*cobra.Command{
+		createTemplateCommand("", "", ""),
+		createTemplateCommand("{{.ShortID", "", ""),
+		createTemplateCommand("{{.ShortID}}", "/nonexistent/template", ""),
+		createTemplateCommand("", "/nonexistent/template", ""),
+		createTemplateCommand(`{{.ShortID | color "mauve"}}`, "", ""),
+		createTemplateCommand(`{{.NoSuchField}}`, "", ""),
+	} {
+		f, err := formatter.NewFormatter(cmd, &bytes.Buffer{})
+		if err == nil {
+			err = f.FormatIssue(&models.Issue{ShortID: "API-1"})
+		}
+		if !errors.As(err, &templateErr) {
+			template, _ := cmd.Flags().GetString("template")
+			t.Errorf("%q: expected a TemplateError, got %v", template, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+)
+
+func TestCrashedFirst(t *testing.T) {
+	threads := []models.Thread{
+		{Name: "a"},
+		{Name: "b", Current: true},
+		{Name: "c"},
+		{Name: "d", Crashed: true},
+	}
+
+	ordered := models.CrashedFirst(threads)
+	var names []string
+	for _, thread := range ordered {
+		names = append(names, thread.Name)
+	}
+	if strings.Join(names, ",") != "d,b,a,c" {
+		t.Errorf("Expected crashed, current, then the rest in order, got %v", names)
+	}
+	if threads[0].Name != "a" {
+		t.Error("Expected the original threads to be left unchanged")
+	}
+}
+
+func TestThreadsInFormatters(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_native.json")
+
+	for _, format := range []string{"text", "table", "markdown", "html", "trace"} {
+		t.Run(format, func(t *testing.T) {
+			cmd := createTestCommand(format)
+			if format == "trace" {
+				cmd = createTraceCommand(false, false)
+			}
+			var buf bytes.Buffer
+			f, err := formatter.NewFormatter(cmd, &buf)
+			if err != nil {
+				t.Fatalf("Failed to create formatter: %v", err)
+			}
+			if err := f.FormatEvent(event); err != nil {
+				t.Fatalf("Failed to format event: %v", err)
+			}
+			output := buf.String()
+
+			worker := strings.Index(output, "worker")
+			if worker < 0 {
+				t.Fatalf("Expected the crashed thread in the output, got:\n%s", output)
+			}
+			if format == "table" {
+				if !strings.Contains(output, "Crashed Thread") {
+					t.Errorf("Expected a crashed thread row, got:\n%s", output)
+				}
+				return
+			}
+			if main := strings.Index(output, "main"); main < 0 || main < worker {
+				t.Errorf("Expected the crashed thread before the main thread, got:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestThreadsList(t *testing.T) {
+	event, _ := loadEventFixture(t, "event_native.json")
+	threads := models.CrashedFirst(event.Threads())
+
+	var buf bytes.Buffer
+	f, _ := formatter.NewFormatter(createTestCommand("csv"), &buf)
+	if err := f.FormatGeneric(threads); err != nil {
+		t.Fatalf("Failed to format threads: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "id,name,state,crashed,current,frames,topFrame" {
+		t.Fatalf("Unexpected CSV output:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "2,worker,") || !strings.Contains(lines[1], ",true,true,2,") {
+		t.Errorf("Expected the crashed thread first, got %q", lines[1])
+	}
+
+	buf.Reset()
+	f, _ = formatter.NewFormatter(createTestCommand("text"), &buf)
+	if err := f.FormatGeneric(threads); err != nil {
+		t.Fatalf("Failed to format threads: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "Threads (2 total):\n\n1. Thread 2 \"worker\" (crashed, current)\n") {
+		t.Errorf("Unexpected text output:\n%s", buf.String())
+	}
+}
+
+func TestThreadsCommand(t *testing.T) {
+	binary := buildSentire(t)
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "event_native.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/projects/my-org/my-project/events/0123456789abcdef0123456789abcdef/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	threads := func(extra ...string) []models.Thread {
+		t.Helper()
+		args := append([]string{"events", "threads", "my-org", "my-project", "0123456789abcdef0123456789abcdef",
+			"--url", server.URL, "--format", "json"}, extra...)
+		stdout, stderr, exitCode := runSentire(t, binary, args...)
+		if exitCode != 0 {
+			t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+		}
+		var result []models.Thread
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("Failed to decode output: %v\n%s", err, stdout)
+		}
+		return result
+	}
+
+	all := threads()
+	if len(all) != 2 || all[0].Name != "worker" || !all[0].Crashed {
+		t.Errorf("Expected the crashed worker thread first, got %+v", all)
+	}
+	if crashed := threads("--crashed"); len(crashed) != 1 || crashed[0].Name != "worker" {
+		t.Errorf("Expected only the crashed thread, got %+v", crashed)
+	}
+	if named := threads("--name", "MAIN"); len(named) != 1 || named[0].Name != "main" {
+		t.Errorf("Expected only the main thread, got %+v", named)
+	}
+	if none := threads("--name", "nothing"); len(none) != 0 {
+		t.Errorf("Expected no threads, got %+v", none)
+	}
+}
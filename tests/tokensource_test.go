@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sentire/internal/config"
+	"strings"
+	"testing"
+)
+
+// writeHelperScript creates an executable shell script that acts as a
+// credential helper, returning its path
+func writeHelperScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper scripts require a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "helper.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0700); err != nil {
+		t.Fatalf("Failed to write helper script: %v", err)
+	}
+	return path
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	helper := writeHelperScript(t, `echo "  helper-token  "; echo "ignored second line"`)
+
+	token, err := (&config.CommandTokenSource{Command: helper}).Token()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token != "helper-token" {
+		t.Errorf("Expected helper-token, got %q", token)
+	}
+}
+
+func TestCommandTokenSourceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"non-zero exit", "exit 3", "exit code 3"},
+		{"empty output", "true", "did not print a token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := writeHelperScript(t, tt.body)
+
+			_, err := (&config.CommandTokenSource{Command: helper}).Token()
+			var authErr *config.AuthError
+			if !errors.As(err, &authErr) {
+				t.Fatalf("Expected AuthError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error to contain %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadConfigCredentialHelper(t *testing.T) {
+	helper := writeHelperScript(t, "echo helper-token")
+	writeTestConfig(t, fmt.Sprintf(`{"credential_helper": %q}`, helper))
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "helper-token" {
+		t.Errorf("Expected token from helper, got %q", cfg.SentryAPIToken)
+	}
+	if !strings.HasPrefix(cfg.Sources[config.KeySentryAPIToken], "credential helper") {
+		t.Errorf("Expected credential helper source, got %q", cfg.Sources[config.KeySentryAPIToken])
+	}
+}
+
+func TestCredentialHelperPrecedence(t *testing.T) {
+	// The helper leaves a marker behind so we can tell whether it ran
+	marker := filepath.Join(t.TempDir(), "ran")
+	helper := writeHelperScript(t, fmt.Sprintf("touch %q; echo profile-helper-token", marker))
+	writeTestConfig(t, fmt.Sprintf(`{
+  "sentry_api_token": "top-level-token",
+  "profiles": {"vault": {"credential_helper": %q}}
+}`, helper))
+
+	// A profile's helper replaces the top-level token
+	cfg, err := config.LoadConfigWithOptions(config.LoadOptions{Profile: "vault"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "profile-helper-token" {
+		t.Errorf("Expected token from profile helper, got %q", cfg.SentryAPIToken)
+	}
+
+	// Resolving defaults does not run the helper
+	os.Remove(marker)
+	if _, err := config.ResolveConfig(config.LoadOptions{Profile: "vault"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected ResolveConfig not to run the credential helper")
+	}
+
+	// An explicit token source overrides everything
+	cfg, err = config.LoadConfigWithOptions(config.LoadOptions{
+		Profile:     "vault",
+		TokenSource: config.StaticTokenSource("static-token"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.SentryAPIToken != "static-token" {
+		t.Errorf("Expected token from TokenSource option, got %q", cfg.SentryAPIToken)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected the overridden credential helper not to run")
+	}
+}
+
+func TestCredentialHelperFailureExitCode(t *testing.T) {
+	binary := buildSentire(t)
+	helper := writeHelperScript(t, "exit 1")
+	writeTestConfig(t, fmt.Sprintf(`{"credential_helper": %q}`, helper))
+
+	cmd := exec.Command(binary, "auth", "status")
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + os.Getenv("HOME")}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 2 {
+		t.Fatalf("Expected exit code 2 for a failing helper, got %v\nstderr: %s", err, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "credential helper") {
+		t.Errorf("Expected error to mention the credential helper, got %q", stderr.String())
+	}
+}
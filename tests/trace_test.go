@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"bytes"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func createTraceCommand(vars, raw bool) *cobra.Command {
+	cmd := createTestCommand("trace")
+	cmd.Flags().Bool("trace-vars", vars, "Test trace-vars flag")
+	cmd.Flags().Bool("trace-raw", raw, "Test trace-raw flag")
+	return cmd
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func chainedEvent() *models.Event {
+	return &models.Event{
+		EventID: "abc123",
+		Title:   "ValueError: invalid user id",
+		Exception: &models.Exception{Values: []models.ExceptionValue{
+			{
+				Type:  "KeyError",
+				Value: "'user'",
+				Stacktrace: &models.Stacktrace{Frames: []models.StackFrame{
+					{Filename: "app/views.py", Function: "load_user", LineNo: intPtr(12), InApp: boolPtr(true),
+						ContextLine: "    return session['user']"},
+				}},
+			},
+			{
+				Type:      "ValueError",
+				Value:     "invalid user id",
+				Mechanism: &models.Mechanism{Type: "django", Handled: boolPtr(false)},
+				Stacktrace: &models.Stacktrace{Frames: []models.StackFrame{
+					{Filename: "django/core/handlers/base.py", Function: "_get_response", LineNo: intPtr(197)},
+					{Filename: "django/core/handlers/base.py", Function: "_wrapped", LineNo: intPtr(58)},
+					{Filename: "app/views.py", Function: "get_user", LineNo: intPtr(42), InApp: boolPtr(true),
+						PreContext:  []string{"def get_user(request):", "    try:"},
+						ContextLine: "        user = load_user(request.session)",
+						PostContext: []string{"    except KeyError as e:"},
+						Vars:        map[string]interface{}{"request": "<WSGIRequest>", "retries": 3}},
+					{Filename: "lib/errors.py", Function: "reraise", LineNo: intPtr(8)},
+				}},
+				RawStacktrace: &models.Stacktrace{Frames: []models.StackFrame{
+					{Filename: "https://cdn.example.com/app.min.js", Function: "a", LineNo: intPtr(1), ColNo: intPtr(4211)},
+				}},
+			},
+		}},
+	}
+}
+
+func TestTraceFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTraceCommand(false, false), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatEvent(chainedEvent()); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+
+	expected := `Event abc123: ValueError: invalid user id
+
+Traceback (most recent call last):
+  File "app/views.py", line 12, in load_user
+  > 12 |     return session['user']
+KeyError: 'user'
+
+The above exception was the direct cause of the following exception:
+
+Traceback (most recent call last):
+  ... 2 system frames hidden ...
+  File "app/views.py", line 42, in get_user
+    40 | def get_user(request):
+    41 |     try:
+  > 42 |         user = load_user(request.session)
+    43 |     except KeyError as e:
+  ... 1 system frame hidden ...
+ValueError: invalid user id (unhandled)
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestTraceVarsAndRaw(t *testing.T) {
+	var vars bytes.Buffer
+	f, _ := formatter.NewFormatter(createTraceCommand(true, false), &vars)
+	if err := f.FormatEvent(chainedEvent()); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+	if !strings.Contains(vars.String(), "    Locals:\n      request = <WSGIRequest>\n      retries = 3\n") {
+		t.Errorf("Expected the frame's local variables, got:\n%s", vars.String())
+	}
+
+	var raw bytes.Buffer
+	f, _ = formatter.NewFormatter(createTraceCommand(false, true), &raw)
+	if err := f.FormatEvent(chainedEvent()); err != nil {
+		t.Fatalf("Failed to format event: %v", err)
+	}
+	output := raw.String()
+	if !strings.Contains(output, `File "https://cdn.example.com/app.min.js", line 1, column 4211, in a`) {
+		t.Errorf("Expected the raw stack trace, got:\n%s", output)
+	}
+	if strings.Contains(output, "get_user") {
+		t.Errorf("Expected the raw stack trace instead of the symbolicated one, got:\n%s", output)
+	}
+	// Exceptions without a raw stack trace keep theirs
+	if !strings.Contains(output, "load_user") {
+		t.Errorf("Expected the stack trace of the cause, got:\n%s", output)
+	}
+}
+
+func TestTraceFallsBackToText(t *testing.T) {
+	var buf bytes.Buffer
+	f, _ := formatter.NewFormatter(createTraceCommand(false, false), &buf)
+	if err := f.FormatProjects([]models.Project{{Name: "API", Slug: "api"}}); err != nil {
+		t.Fatalf("Failed to format projects: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "Projects (1 total):") {
+		t.Errorf("Expected projects as text, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	f.Begin()
+	f.Record(models.Event{EventID: "e1", Message: "disk full"})
+	f.Record(models.Event{EventID: "e2", Message: "disk still full"})
+	f.End()
+	if buf.String() != "Event e1: disk full\n\nNo stack trace\n\nEvent e2: disk still full\n\nNo stack trace\n" {
+		t.Errorf("Unexpected streamed traces:\n%s", buf.String())
+	}
+}
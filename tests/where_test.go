@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sentire/internal/filter"
+	"sentire/pkg/models"
+	"strings"
+	"testing"
+)
+
+func TestWhereMatch(t *testing.T) {
+	issue := models.Issue{ID: "1", Level: "error", Count: "42", UserCount: 12, Project: models.IssueProject{Slug: "api"}}
+	event := models.Event{
+		Tags: []models.EventTag{{Key: "browser", Value: "Chrome 126"}, {Key: "os.name", Value: "Mac OS X"}},
+		Entries: []models.Entry{{Type: "exception", Data: map[string]interface{}{
+			"values": []interface{}{map[string]interface{}{"type": "ValueError", "value": "bad input"}},
+		}}},
+		Contexts: &models.Contexts{OS: &models.OSContext{Name: "Linux"}},
+	}
+
+	tests := []struct {
+		expr     string
+		record   interface{}
+		expected bool
+	}{
+		{`level == "error" && userCount > 10`, issue, true},
+		{`level == "error" && userCount > 20`, issue, false},
+		{`level != "error" || project.slug == 'api'`, issue, true},
+		{`count >= 42 && count < 43`, issue, true},
+		{`!(level == "warning")`, issue, true},
+		{`assignedTo == null && !assignedTo`, issue, true},
+		{`tags.browser =~ "^Chrome"`, event, true},
+		{`tags["os.name"] !~ "Windows"`, event, true},
+		{`tags["release-stage"] == null`, event, true},
+		{`tags.missing =~ ".*"`, event, false},
+		{`exception.values[0].type == "ValueError"`, event, true},
+		{`exception.values[1].type == "ValueError"`, event, false},
+		{`contexts.os.name == "Linux"`, event, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := filter.Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if got := expr.Match(tt.record); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWhereSyntaxErrors(t *testing.T) {
+	for _, expr := range []string{
+		`level ==`,
+		`level == "error`,
+		`(level == "error"`,
+		`level =~ error`,
+		`level =~ "("`,
+		`values[x]`,
+		`level = "error"`,
+		`userCount-1 > 3`,
+		`a-b`,
+	} {
+		var syntaxErr *filter.SyntaxError
+		if _, err := filter.Parse(expr); !errors.As(err, &syntaxErr) {
+			t.Errorf("%s: expected a SyntaxError, got %v", expr, err)
+		}
+	}
+}
+
+func TestListIssuesWhere(t *testing.T) {
+	binary := buildSentire(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "1", "level": "error", "userCount": 50}, {"id": "2", "level": "warning", "userCount": 50}, {"id": "3", "level": "error", "userCount": 1}]`))
+	}))
+	defer server.Close()
+
+	stdout, stderr, exitCode := runSentire(t, binary, "events", "list-issues", "my-org",
+		"--where", `level == "error" && userCount > 10`, "--format", "ndjson", "--fields", "id", "--url", server.URL)
+	if exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr: %s", exitCode, stderr)
+	}
+	if strings.TrimSpace(stdout) != `{"id":"1"}` {
+		t.Errorf("Expected only issue 1, got %q", stdout)
+	}
+
+	_, stderr, exitCode = runSentire(t, binary, "events", "list-issues", "my-org", "--where", `level ==`, "--url", server.URL)
+	if exitCode != 4 || !strings.Contains(stderr, "invalid --where") {
+		t.Errorf("Expected exit code 4 for an invalid expression, got %d: %s", exitCode, stderr)
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"bytes"
+	"sentire/internal/cli/formatter"
+	"sentire/pkg/models"
+	"testing"
+	"time"
+)
+
+func TestYAMLIssue(t *testing.T) {
+	issue := &models.Issue{
+		ID:        "1",
+		ShortID:   "API-1",
+		Title:     "KeyError: 'user'",
+		Count:     "42",
+		UserCount: 7,
+		LastSeen:  time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		Metadata:  map[string]interface{}{"value": "line one\nline two"},
+	}
+
+	var buf bytes.Buffer
+	f, err := formatter.NewFormatter(createTestCommandWithFields("yaml", "id,title,count,userCount,lastSeen,metadata,isPublic"), &buf)
+	if err != nil {
+		t.Fatalf("Failed to create formatter: %v", err)
+	}
+	if err := f.FormatIssue(issue); err != nil {
+		t.Fatalf("Failed to format issue: %v", err)
+	}
+
+	expected := `id: "1"
+title: 'KeyError: ''user'''
+count: "42"
+userCount: 7
+lastSeen: "2026-05-01T12:00:00Z"
+metadata:
+  value: |-
+    line one
+    line two
+isPublic: false
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestYAMLStreamMatchesBatch(t *testing.T) {
+	projects := []models.Project{
+		{ID: "1", Slug: "api", Platforms: []string{"python", "go"}},
+		{ID: "2", Slug: "web"},
+	}
+	fields := "id,slug,platforms"
+
+	var batch bytes.Buffer
+	f, _ := formatter.NewFormatter(createTestCommandWithFields("yaml", fields), &batch)
+	if err := f.FormatProjects(projects); err != nil {
+		t.Fatalf("Failed to format projects: %v", err)
+	}
+
+	var streamed bytes.Buffer
+	f, _ = formatter.NewFormatter(createTestCommandWithFields("yaml", fields), &streamed)
+	f.Begin()
+	for _, project := range projects {
+		f.Record(project)
+	}
+	f.End()
+
+	if batch.String() != streamed.String() {
+		t.Errorf("Expected streamed output to match:\n%s\nGot:\n%s", batch.String(), streamed.String())
+	}
+
+	var empty bytes.Buffer
+	f, _ = formatter.NewFormatter(createTestCommand("yaml"), &empty)
+	f.Begin()
+	f.End()
+	if empty.String() != "[]\n" {
+		t.Errorf("Expected an empty sequence, got %q", empty.String())
+	}
+}